@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/usecase/status"
+)
+
+const (
+	// ErrDuplicateAppCode is returned when application error code is already registered.
+	ErrDuplicateAppCode = sentinelError("duplicate application error code")
+
+	// ErrInvalidAppError is returned when application error misses application or status code.
+	ErrInvalidAppError = sentinelError("invalid application error")
+)
+
+// AppError describes application error registered in ErrorCatalogue.
+type AppError struct {
+	AppCode    int
+	StatusCode status.Code
+
+	// Message is a template of error message, placeholders like {name} are filled from Error.Context.
+	Message string
+
+	// Description explains error conditions for documentation purposes.
+	Description string
+}
+
+// ErrorCatalogue is a registry of application errors.
+//
+// Zero value is ready to use.
+type ErrorCatalogue struct {
+	mu     sync.RWMutex
+	errors map[int]AppError
+}
+
+// Register adds application error to catalogue.
+//
+// It fails with ErrInvalidAppError if application code or status code is zero, because such error
+// can not be matched by Error.Is or rendered, and with ErrDuplicateAppCode if application code is
+// already registered.
+func (c *ErrorCatalogue) Register(e AppError) error {
+	if e.AppCode == 0 {
+		return fmt.Errorf("%w: zero application code for %q", ErrInvalidAppError, e.Message)
+	}
+
+	if e.StatusCode == 0 {
+		return fmt.Errorf("%w: zero status code for application code %d", ErrInvalidAppError, e.AppCode)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.errors == nil {
+		c.errors = make(map[int]AppError)
+	}
+
+	if prev, found := c.errors[e.AppCode]; found {
+		return fmt.Errorf("%w: %d, already used by %q", ErrDuplicateAppCode, e.AppCode, prev.Message)
+	}
+
+	c.errors[e.AppCode] = e
+
+	return nil
+}
+
+// MustRegister adds application error to catalogue and returns its sample to be used in expected errors.
+//
+// It panics if application error is invalid or application code is already registered.
+func (c *ErrorCatalogue) MustRegister(e AppError) Error {
+	if err := c.Register(e); err != nil {
+		panic(err)
+	}
+
+	return c.New(e.AppCode, nil)
+}
+
+// Lookup finds application error by code.
+func (c *ErrorCatalogue) Lookup(appCode int) (AppError, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, found := c.errors[appCode]
+
+	return e, found
+}
+
+// New creates Error of registered application code with message template filled from context parameters.
+//
+// Filled message is also used as a public message of Error.
+// Unknown application code results in Error with status.Unknown.
+func (c *ErrorCatalogue) New(appCode int, context map[string]interface{}) Error {
+	e, found := c.Lookup(appCode)
+	if !found {
+		return Error{
+			AppCode:    appCode,
+			StatusCode: status.Unknown,
+			Context:    context,
+		}
+	}
+
+	err := Error{
+		AppCode:    appCode,
+		StatusCode: e.StatusCode,
+		Context:    context,
+	}
+
+	if e.Message != "" {
+		msg := formatMessage(e.Message, context)
+		err.Value = sentinelError(msg)
+		err.Public = msg
+	}
+
+	return err
+}
+
+// List returns registered application errors ordered by code.
+func (c *ErrorCatalogue) List() []AppError {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	res := make([]AppError, 0, len(c.errors))
+	for _, e := range c.errors {
+		res = append(res, e)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].AppCode < res[j].AppCode
+	})
+
+	return res
+}
+
+type appErrorJSON struct {
+	AppCode     int    `json:"appCode"`
+	Status      string `json:"status"`
+	StatusCode  int    `json:"statusCode"`
+	Message     string `json:"message,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// MarshalJSON exports catalogue as a JSON array of registered errors.
+func (c *ErrorCatalogue) MarshalJSON() ([]byte, error) {
+	list := c.List()
+	res := make([]appErrorJSON, 0, len(list))
+
+	for _, e := range list {
+		res = append(res, appErrorJSON{
+			AppCode:     e.AppCode,
+			Status:      e.StatusCode.String(),
+			StatusCode:  int(e.StatusCode),
+			Message:     e.Message,
+			Description: e.Description,
+		})
+	}
+
+	return json.Marshal(res)
+}
+
+// WriteMarkdown exports catalogue as a Markdown table.
+func (c *ErrorCatalogue) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("| App Code | Status | Message | Description |\n")
+	sb.WriteString("|----------|--------|---------|-------------|\n")
+
+	for _, e := range c.List() {
+		sb.WriteString(fmt.Sprintf("| %d | %s | %s | %s |\n",
+			e.AppCode, e.StatusCode.String(), markdownCell(e.Message), markdownCell(e.Description)))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+var markdownCellReplacer = strings.NewReplacer(
+	"|", "\\|",
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
+
+// formatMessage fills {name} placeholders of template with parameter values.
+//
+// Placeholders without matching parameters are left intact, "{{" and "}}" are unescaped to literal braces.
+func formatMessage(template string, params map[string]interface{}) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(len(template))
+
+	for i := 0; i < len(template); i++ {
+		ch := template[i]
+
+		switch {
+		case ch == '{' && i+1 < len(template) && template[i+1] == '{':
+			sb.WriteByte('{')
+			i++
+		case ch == '}' && i+1 < len(template) && template[i+1] == '}':
+			sb.WriteByte('}')
+			i++
+		case ch == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end == -1 {
+				sb.WriteString(template[i:])
+
+				return sb.String()
+			}
+
+			name := strings.TrimSpace(template[i+1 : i+end])
+			if v, found := params[name]; found {
+				sb.WriteString(fmt.Sprint(v))
+			} else {
+				sb.WriteString(template[i : i+end+1])
+			}
+
+			i += end
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+func TestErrorCatalogue(t *testing.T) {
+	c := usecase.ErrorCatalogue{}
+
+	require.NoError(t, c.Register(usecase.AppError{
+		AppCode:     1002,
+		StatusCode:  status.NotFound,
+		Message:     "order {id} not found",
+		Description: "Order does not exist or was deleted.",
+	}))
+
+	sample := c.MustRegister(usecase.AppError{
+		AppCode:     1001,
+		StatusCode:  status.InvalidArgument,
+		Message:     "amount {amount} exceeds {{limit}}",
+		Description: "Amount | limit\nis checked.",
+	})
+	assert.Equal(t, 1001, sample.AppCode)
+	assert.Equal(t, status.InvalidArgument, sample.StatusCode)
+
+	err := c.Register(usecase.AppError{AppCode: 1002, StatusCode: status.Internal})
+	assert.True(t, errors.Is(err, usecase.ErrDuplicateAppCode))
+	assert.EqualError(t, err, `duplicate application error code: 1002, already used by "order {id} not found"`)
+	assert.Panics(t, func() {
+		c.MustRegister(usecase.AppError{AppCode: 1001, StatusCode: status.Internal})
+	})
+
+	err = c.Register(usecase.AppError{AppCode: 7})
+	assert.True(t, errors.Is(err, usecase.ErrInvalidAppError))
+	assert.EqualError(t, err, "invalid application error: zero status code for application code 7")
+
+	err = c.Register(usecase.AppError{StatusCode: status.NotFound, Message: "missing"})
+	assert.True(t, errors.Is(err, usecase.ErrInvalidAppError))
+	assert.EqualError(t, err, `invalid application error: zero application code for "missing"`)
+
+	_, found := c.Lookup(7)
+	assert.False(t, found)
+
+	e := c.New(1002, map[string]interface{}{"id": 123})
+	assert.Equal(t, 1002, e.AppCode)
+	assert.Equal(t, status.NotFound, e.StatusCode)
+	assert.EqualError(t, e, "not found: order 123 not found")
+	assert.Equal(t, "order 123 not found", usecase.PublicMessage(e))
+	assert.True(t, errors.Is(e, status.NotFound))
+
+	e = c.New(1002, nil)
+	assert.EqualError(t, e, "not found: order {id} not found")
+
+	e = c.New(1001, map[string]interface{}{"amount": 10})
+	assert.EqualError(t, e, "invalid argument: amount 10 exceeds {limit}")
+
+	e = c.New(999, nil)
+	assert.Equal(t, status.Unknown, e.StatusCode)
+
+	ae, found := c.Lookup(1001)
+	assert.True(t, found)
+	assert.Equal(t, status.InvalidArgument, ae.StatusCode)
+
+	_, found = c.Lookup(999)
+	assert.False(t, found)
+
+	list := c.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, 1001, list[0].AppCode)
+	assert.Equal(t, 1002, list[1].AppCode)
+
+	j, err := json.Marshal(&c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+	  {"appCode":1001,"status":"INVALID_ARGUMENT","statusCode":3,"message":"amount {amount} exceeds {{limit}}","description":"Amount | limit\nis checked."},
+	  {"appCode":1002,"status":"NOT_FOUND","statusCode":5,"message":"order {id} not found","description":"Order does not exist or was deleted."}
+	]`, string(j))
+
+	md := bytes.NewBuffer(nil)
+	require.NoError(t, c.WriteMarkdown(md))
+	assert.Equal(t, `| App Code | Status | Message | Description |
+|----------|--------|---------|-------------|
+| 1001 | INVALID_ARGUMENT | amount {amount} exceeds {{limit}} | Amount \| limit<br>is checked. |
+| 1002 | NOT_FOUND | order {id} not found | Order does not exist or was deleted. |
+`, md.String())
+}
@@ -1,6 +1,14 @@
 package usecase
 
-import "github.com/swaggest/usecase/status"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+
+	"github.com/swaggest/usecase/status"
+)
 
 // Error is an error with contextual information.
 type Error struct {
@@ -8,6 +16,64 @@ type Error struct {
 	StatusCode status.Code
 	Value      error
 	Context    map[string]interface{}
+
+	// Public is a message that is safe to show to API clients, Value may hold internal details.
+	Public string
+
+	stack []uintptr
+}
+
+// WithStack returns a copy of Error with captured stack trace of the caller.
+//
+// Stack trace is not collected unless requested, so plain Error is free of its cost.
+func (e Error) WithStack() Error {
+	e.stack = callers(1)
+
+	return e
+}
+
+// StackTrace returns stack trace captured with WithStack, or nil.
+func (e Error) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	res := make([]runtime.Frame, 0, len(e.stack))
+	frames := runtime.CallersFrames(e.stack)
+
+	for {
+		f, more := frames.Next()
+		res = append(res, f)
+
+		if !more {
+			break
+		}
+	}
+
+	return res
+}
+
+// Format implements fmt.Formatter, "%+v" prints error message with stack trace,
+// "%#v" prints Go-syntax representation of Error.
+func (e Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('#'):
+		// Method-less type prevents recursion into Format, its name is replaced with Error.
+		type plain Error
+
+		str := fmt.Sprintf("%#v", plain(e))
+		_, _ = io.WriteString(s, "usecase.Error"+str[strings.IndexByte(str, '{'):])
+	case verb == 'v' && s.Flag('+'):
+		_, _ = io.WriteString(s, e.Error())
+
+		for _, f := range e.StackTrace() {
+			_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
+		}
+	case verb == 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
+	default:
+		_, _ = io.WriteString(s, e.Error())
+	}
 }
 
 // Error returns error message.
@@ -20,6 +86,11 @@ func (e Error) Fields() map[string]interface{} {
 	return e.Context
 }
 
+// PublicMessage returns message that is safe to show to API clients, empty if not set.
+func (e Error) PublicMessage() string {
+	return e.Public
+}
+
 // AppErrCode returns application level error code.
 func (e Error) AppErrCode() int {
 	return e.AppCode
@@ -30,6 +101,48 @@ func (e Error) Status() status.Code {
 	return e.StatusCode
 }
 
+// Is implements interface for errors.Is.
+//
+// Target Error (or *Error) matches if its AppCode is equal to AppCode of this error, and its
+// StatusCode is equal to StatusCode of this error when both are set. Target without AppCode and
+// StatusCode does not match.
+func (e Error) Is(target error) bool {
+	var t Error
+
+	switch v := target.(type) {
+	case Error:
+		t = v
+	case *Error:
+		if v == nil {
+			return false
+		}
+
+		t = *v
+	default:
+		return false
+	}
+
+	matched := false
+
+	if t.AppCode != 0 {
+		if t.AppCode != e.AppCode {
+			return false
+		}
+
+		matched = true
+	}
+
+	if t.StatusCode != 0 && e.StatusCode != 0 {
+		if t.StatusCode != e.StatusCode {
+			return false
+		}
+
+		matched = true
+	}
+
+	return matched
+}
+
 // Unwrap returns parent error.
 func (e Error) Unwrap() error {
 	if e.StatusCode != 0 {
@@ -44,3 +157,39 @@ type sentinelError string
 func (s sentinelError) Error() string {
 	return string(s)
 }
+
+// PublicMessage returns the safest message of error chain to show to API clients.
+//
+// The first non-empty message of PublicMessage() string method in error chain is used.
+// Otherwise, errors with status.Internal, status.Unknown or without status code are
+// reduced to status message to avoid leaking internal details, and other errors
+// are returned as is.
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if p, ok := e.(interface{ PublicMessage() string }); ok {
+			if msg := p.PublicMessage(); msg != "" {
+				return msg
+			}
+		}
+	}
+
+	code := StatusOf(err)
+	if code == 0 {
+		code = status.Unknown
+	}
+
+	if IsInternal(code) {
+		return code.Error()
+	}
+
+	return err.Error()
+}
+
+// IsInternal checks if status code denotes error with internal details that should not be shown to API clients.
+func IsInternal(code status.Code) bool {
+	return code == status.Internal || code == status.Unknown
+}
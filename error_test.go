@@ -2,9 +2,12 @@ package usecase_test
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/swaggest/usecase"
 	"github.com/swaggest/usecase/status"
 )
@@ -31,3 +34,86 @@ func TestError(t *testing.T) {
 	assert.EqualError(t, e, "failed")
 	assert.EqualError(t, e.Unwrap(), "failed")
 }
+
+func TestError_WithStack(t *testing.T) {
+	e := usecase.Error{StatusCode: status.NotFound, Value: errors.New("failed")}
+	assert.Nil(t, e.StackTrace())
+	assert.Equal(t, "not found: failed", fmt.Sprintf("%+v", e))
+
+	e = newErrorWithStack()
+	st := e.StackTrace()
+	require.NotEmpty(t, st)
+	assert.Equal(t, "github.com/swaggest/usecase_test.newErrorWithStack", st[0].Function)
+	assert.True(t, strings.HasSuffix(st[0].File, "error_test.go"))
+	assert.Equal(t, "github.com/swaggest/usecase_test.TestError_WithStack", st[1].Function)
+
+	assert.Equal(t, "not found: failed", fmt.Sprintf("%v", e))
+	assert.Equal(t, "not found: failed", fmt.Sprintf("%s", e))
+	assert.Equal(t, `"not found: failed"`, fmt.Sprintf("%q", e))
+	assert.Equal(t, "not found: failed", e.Error())
+	assert.Equal(t, `usecase.Error{AppCode:3, StatusCode:5, Value:error(nil), Context:map[string]interface {}(nil), `+
+		`Public:"x", stack:[]uintptr(nil)}`,
+		fmt.Sprintf("%#v", usecase.Error{AppCode: 3, StatusCode: status.NotFound, Public: "x"}))
+
+	s := fmt.Sprintf("%+v", e)
+	assert.True(t, strings.HasPrefix(s, "not found: failed\ngithub.com/swaggest/usecase_test.newErrorWithStack\n\t"), s)
+	assert.Contains(t, s, "\ngithub.com/swaggest/usecase_test.TestError_WithStack\n\t")
+}
+
+func newErrorWithStack() usecase.Error {
+	return usecase.Error{StatusCode: status.NotFound, Value: errors.New("failed")}.WithStack()
+}
+
+func TestPublicMessage(t *testing.T) {
+	assert.Equal(t, "", usecase.PublicMessage(nil))
+
+	// Internal details are hidden.
+	assert.Equal(t, "unknown", usecase.PublicMessage(errors.New("pq: connection refused")))
+	assert.Equal(t, "internal", usecase.PublicMessage(status.Wrap(errors.New("pq: connection refused"), status.Internal)))
+	assert.Equal(t, "internal", usecase.PublicMessage(usecase.Error{
+		StatusCode: status.Internal,
+		Value:      errors.New("pq: connection refused"),
+	}))
+
+	// Non-internal errors are shown as is.
+	assert.Equal(t, "invalid argument: bad name", usecase.PublicMessage(usecase.Error{
+		StatusCode: status.InvalidArgument,
+		Value:      errors.New("bad name"),
+	}))
+
+	// Public message takes precedence.
+	e := usecase.Error{
+		StatusCode: status.Internal,
+		Value:      errors.New("pq: connection refused"),
+		Public:     "Service is temporarily unavailable.",
+	}
+	assert.Equal(t, "Service is temporarily unavailable.", e.PublicMessage())
+	assert.Equal(t, "Service is temporarily unavailable.", usecase.PublicMessage(fmt.Errorf("storing order: %w", e)))
+	assert.EqualError(t, e, "internal: pq: connection refused")
+
+	assert.True(t, usecase.IsInternal(status.Unknown))
+	assert.False(t, usecase.IsInternal(status.NotFound))
+}
+
+func TestError_Is(t *testing.T) {
+	e := usecase.Error{
+		AppCode:    1001,
+		StatusCode: status.NotFound,
+		Value:      errors.New("order not found"),
+	}
+	wrapped := fmt.Errorf("loading order: %w", e)
+
+	assert.True(t, errors.Is(wrapped, usecase.Error{AppCode: 1001}))
+	assert.True(t, errors.Is(wrapped, &usecase.Error{AppCode: 1001, StatusCode: status.NotFound}))
+	assert.True(t, errors.Is(wrapped, usecase.Error{StatusCode: status.NotFound}))
+	assert.True(t, errors.Is(wrapped, status.NotFound))
+
+	assert.False(t, errors.Is(wrapped, usecase.Error{AppCode: 1002}))
+	assert.False(t, errors.Is(wrapped, usecase.Error{AppCode: 1001, StatusCode: status.Internal}))
+	assert.False(t, errors.Is(wrapped, usecase.Error{}))
+	assert.False(t, errors.Is(wrapped, (*usecase.Error)(nil)))
+
+	// Status code is not compared if not set.
+	assert.True(t, errors.Is(usecase.Error{AppCode: 1001}, usecase.Error{AppCode: 1001, StatusCode: status.NotFound}))
+	assert.False(t, errors.Is(usecase.Error{StatusCode: status.NotFound}, usecase.Error{AppCode: 1001, StatusCode: status.NotFound}))
+}
@@ -49,13 +49,12 @@ func ExampleNewIOI() {
 
 	// At transport layer, input and out ports are to be examined and populated using reflection.
 	// For example request body could be json unmarshaled, or request parameters can be mapped.
-	input := new(myInput)
-	// input := reflect.New(reflect.TypeOf(u.InputPort()))
+	// Fresh port values are created with usecase.NewInput and usecase.NewOutput.
+	input := usecase.NewInput(u).(*myInput)
 	input.Param1 = 1234
 	input.Param2 = "abc"
 
-	output := new(myOutput)
-	// output := reflect.New(reflect.TypeOf(u.OutputPort()))
+	output := usecase.NewOutput(u)
 
 	// When input is prepared and output is initialized, transport should invoke interaction.
 	err := u.Interact(context.TODO(), input, output)
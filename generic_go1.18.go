@@ -6,6 +6,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"reflect"
 )
 
 // ErrInvalidType is returned on port type assertion error.
@@ -23,11 +24,33 @@ func (ioi IOInteractorOf[i, o]) Invoke(ctx context.Context, input i, output *o)
 	return ioi.InteractFunc(ctx, input, output)
 }
 
+// Validate checks input and output ports.
+func (ioi IOInteractorOf[i, o]) Validate() error {
+	return Validate(ioi)
+}
+
+func (ioi IOInteractorOf[i, o]) inputType() reflect.Type {
+	return reflect.TypeOf((*i)(nil)).Elem()
+}
+
 // NewInteractor creates generic use case interactor with input and output ports.
 //
 // It pre-fills name and title with caller function.
 // Input is passed by value, while output is passed by pointer to be mutable.
 func NewInteractor[i, o any](interact func(ctx context.Context, input i, output *o) error, options ...func(i *IOInteractor)) IOInteractorOf[i, o] {
+	u := newInteractorOf(interact)
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u.IOInteractor)
+	}
+
+	return u
+}
+
+func newInteractorOf[i, o any](interact func(ctx context.Context, input i, output *o) error) IOInteractorOf[i, o] {
 	u := IOInteractorOf[i, o]{}
 	u.Input = *new(i)
 	u.Output = new(o)
@@ -46,12 +69,5 @@ func NewInteractor[i, o any](interact func(ctx context.Context, input i, output
 		return interact(ctx, inp, out)
 	})
 
-	u.name, u.title = callerFunc()
-	u.name = filterName(u.name)
-
-	for _, o := range options {
-		o(&u.IOInteractor)
-	}
-
 	return u
 }
@@ -5,6 +5,8 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"strconv"
 	"testing"
 
@@ -59,3 +61,49 @@ func TestNewInteractor(t *testing.T) {
 
 	assert.Equal(t, []string{"foo"}, u.Tags())
 }
+
+func TestIOInteractorOf_Validate(t *testing.T) {
+	u := usecase.NewInteractor(func(ctx context.Context, input int, output **int) error {
+		return nil
+	})
+	u.SetName("double")
+
+	assert.EqualError(t, u.Validate(), "double: invalid port: output **int is a pointer to pointer")
+	assert.NoError(t, usecase.NewInteractor(func(ctx context.Context, input int, output *int) error {
+		return nil
+	}).Validate())
+}
+
+func TestNewInput_generic(t *testing.T) {
+	u := usecase.NewInteractor(func(ctx context.Context, input int, output *string) error {
+		*output = strconv.Itoa(input)
+
+		return nil
+	})
+
+	in := usecase.NewInput(u)
+	out := usecase.NewOutput(u)
+
+	assert.Equal(t, 0, in)
+	assert.Equal(t, new(string), out)
+	assert.NoError(t, u.Interact(context.Background(), in, out))
+	assert.Equal(t, "0", *out.(*string))
+}
+
+func TestValidatePorts_embeddedStream(t *testing.T) {
+	assert.NoError(t, usecase.ValidatePorts(nil, new(struct{ usecase.OutputWithStream[int] })))
+}
+
+func TestIOInteractorOf_Validate_interfaceInput(t *testing.T) {
+	u := usecase.NewInteractor(func(ctx context.Context, input io.Reader, output *int) error {
+		return nil
+	})
+	u.SetName("read")
+
+	err := u.Validate()
+	assert.True(t, errors.Is(err, usecase.ErrInvalidPort))
+	assert.EqualError(t, err, "read: invalid port: input io.Reader is an interface")
+
+	w := usecase.Wrap(u, usecase.ErrorCatcher(func(ctx context.Context, input interface{}, err error) {}))
+	assert.EqualError(t, usecase.Validate(w), "read: invalid port: input io.Reader is an interface")
+}
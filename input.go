@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"reflect"
+)
+
+// InputWithReader defines input with streaming reader.
+type InputWithReader interface {
+	SetReader(r io.Reader)
+}
+
+// InputWithEmbeddedReader implements streaming use case input.
+type InputWithEmbeddedReader struct {
+	io.Reader
+}
+
+// SetReader implements InputWithReader.
+func (i *InputWithEmbeddedReader) SetReader(r io.Reader) {
+	i.Reader = r
+}
+
+// InputWithItemStream defines input with stream of typed items, see InputWithStream.
+type InputWithItemStream interface {
+	// StreamItem returns sample of stream item value.
+	StreamItem() interface{}
+
+	// SetItemSource sets provider of stream items, it should return io.EOF at the end of stream.
+	SetItemSource(next func(ctx context.Context) (interface{}, error))
+}
+
+// IsStreaming checks if port streams its content instead of having it in memory.
+//
+// Port is streaming if it (or pointer to it) implements any of InputWithReader, InputWithItemStream,
+// OutputWithWriter, OutputWithItemStream.
+func IsStreaming(port interface{}) bool {
+	if port == nil {
+		return false
+	}
+
+	if isStreaming(port) {
+		return true
+	}
+
+	if t := reflect.TypeOf(port); t.Kind() != reflect.Ptr {
+		return isStreaming(reflect.New(t).Interface())
+	}
+
+	return false
+}
+
+func isStreaming(port interface{}) bool {
+	switch port.(type) {
+	case InputWithReader, InputWithItemStream, OutputWithWriter, OutputWithItemStream:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+)
+
+type uploadInput struct {
+	usecase.InputWithEmbeddedReader
+	Name string `query:"name"`
+}
+
+func TestInputWithEmbeddedReader_SetReader(t *testing.T) {
+	in := uploadInput{}
+
+	var i usecase.InputWithReader = &in
+
+	i.SetReader(strings.NewReader("hello"))
+
+	b, err := ioutil.ReadAll(in)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+}
+
+func TestIsStreaming(t *testing.T) {
+	type plain struct {
+		Name string
+	}
+
+	type download struct {
+		usecase.OutputWithEmbeddedWriter
+	}
+
+	assert.False(t, usecase.IsStreaming(nil))
+	assert.False(t, usecase.IsStreaming(plain{}))
+	assert.False(t, usecase.IsStreaming(new(plain)))
+	assert.True(t, usecase.IsStreaming(new(uploadInput)))
+	assert.True(t, usecase.IsStreaming(uploadInput{}))
+	assert.True(t, usecase.IsStreaming(new(download)))
+}
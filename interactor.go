@@ -177,6 +177,7 @@ type IOInteractor struct {
 // NewIOI creates use case interactor with input, output and interact action function.
 //
 // It pre-fills name and title with caller function.
+// Ports are not checked, use Validate to detect unusable ports early.
 func NewIOI(input, output interface{}, interact Interact, options ...func(i *IOInteractor)) IOInteractor {
 	u := IOInteractor{}
 	u.Input = input
@@ -242,6 +243,17 @@ func callerFunc() (string, string) {
 	return pathName, title
 }
 
+// callers returns program counters of call stack, skip is a number of frames to omit above the caller of callers.
+func callers(skip int) []uintptr {
+	const maxDepth = 32
+
+	var pcs [maxDepth]uintptr
+
+	n := runtime.Callers(skip+2, pcs[:])
+
+	return pcs[0:n]
+}
+
 // borrowed from https://pkg.go.dev/github.com/fatih/camelcase#Split to avoid external dependency.
 func splitCamelcase(src string) string { //nolint:cyclop
 	// don't split invalid utf8
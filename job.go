@@ -0,0 +1,480 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/swaggest/usecase/status"
+)
+
+// JobStatus is a state of asynchronous job.
+type JobStatus string
+
+// Job statuses.
+const (
+	JobQueued    = JobStatus("queued")
+	JobRunning   = JobStatus("running")
+	JobSucceeded = JobStatus("succeeded")
+	JobFailed    = JobStatus("failed")
+	JobCanceled  = JobStatus("canceled")
+)
+
+// Job is a state of asynchronous use case interaction.
+type Job struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Status   JobStatus `json:"status"`
+	Progress Progress  `json:"progress"`
+
+	// Output is a value of use case output port, available for succeeded job.
+	Output interface{} `json:"output,omitempty"`
+
+	// Error and StatusCode describe failure of a job.
+	Error      string      `json:"error,omitempty"`
+	StatusCode status.Code `json:"statusCode"`
+}
+
+// Finished checks if job is in a final state.
+func (j Job) Finished() bool {
+	return j.Status == JobSucceeded || j.Status == JobFailed || j.Status == JobCanceled
+}
+
+// JobStore persists job states.
+type JobStore interface {
+	SaveJob(ctx context.Context, job Job) error
+
+	// LoadJob fails with status.NotFound if job does not exist.
+	LoadJob(ctx context.Context, id string) (Job, error)
+}
+
+// InMemoryJobStore is a JobStore that keeps jobs in memory.
+//
+// Zero value is ready to use.
+type InMemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+var _ JobStore = &InMemoryJobStore{}
+
+// SaveJob implements JobStore.
+func (m *InMemoryJobStore) SaveJob(_ context.Context, job Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.jobs == nil {
+		m.jobs = make(map[string]Job)
+	}
+
+	m.jobs[job.ID] = job
+
+	return nil
+}
+
+// LoadJob implements JobStore.
+func (m *InMemoryJobStore) LoadJob(_ context.Context, id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, found := m.jobs[id]
+	if !found {
+		return job, fmt.Errorf("job %s: %w", id, status.NotFound)
+	}
+
+	return job, nil
+}
+
+// JobRunner runs use case interactions asynchronously on a bounded worker pool.
+//
+// Progress that interaction reports with ReportProgress, or with output that implements
+// OutputWithProgress, is saved to job state.
+type JobRunner struct {
+	// Store keeps job states, InMemoryJobStore is used by default.
+	Store JobStore
+
+	// QueueSize limits number of jobs waiting for a worker, default 100.
+	QueueSize int
+
+	// OnStoreError is called when job state can not be saved, optional.
+	OnStoreError func(ctx context.Context, job Job, err error)
+
+	queue   chan jobTask
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	closed  bool
+	cancels map[string]context.CancelFunc
+}
+
+type jobTask struct {
+	ctx    context.Context //nolint:containedctx // Context is passed to worker.
+	job    Job
+	u      Interactor
+	input  interface{}
+	output interface{}
+}
+
+// NewJobRunner creates job runner and starts workers.
+func NewJobRunner(workers int, options ...func(r *JobRunner)) *JobRunner {
+	r := &JobRunner{
+		QueueSize: 100,
+		cancels:   make(map[string]context.CancelFunc),
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	if r.Store == nil {
+		r.Store = &InMemoryJobStore{}
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	r.queue = make(chan jobTask, r.QueueSize)
+
+	for i := 0; i < workers; i++ {
+		r.wg.Add(1)
+
+		go r.work()
+	}
+
+	return r
+}
+
+// Submit queues use case interaction and returns job ID.
+//
+// Values of context are available during interaction, but its cancellation is not.
+// Input must not be reused by caller until job is finished.
+// It fails with status.ResourceExhausted if queue is full.
+func (r *JobRunner) Submit(ctx context.Context, u Interactor, input interface{}) (string, error) {
+	job := Job{
+		ID:     newID(),
+		Name:   stepName(u, 0),
+		Status: JobQueued,
+	}
+
+	jobCtx, cancel := context.WithCancel(detachedContext{parent: ctx})
+
+	task := jobTask{
+		ctx:    jobCtx,
+		job:    job,
+		u:      u,
+		input:  input,
+		output: NewOutput(u),
+	}
+
+	if err := r.Store.SaveJob(ctx, job); err != nil {
+		cancel()
+
+		return "", fmt.Errorf("saving job: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+
+	if r.closed {
+		err = fmt.Errorf("job runner is closed: %w", status.Unavailable)
+	} else {
+		select {
+		case r.queue <- task:
+			r.cancels[job.ID] = cancel
+
+			return job.ID, nil
+		default:
+			err = fmt.Errorf("job queue is full: %w", status.ResourceExhausted)
+		}
+	}
+
+	cancel()
+
+	job.Status = JobCanceled
+	job.StatusCode = StatusOf(err)
+	job.Error = err.Error()
+
+	r.save(ctx, job)
+
+	return "", err
+}
+
+// Job returns state of a job, it fails with status.NotFound if job does not exist.
+func (r *JobRunner) Job(ctx context.Context, id string) (Job, error) {
+	return r.Store.LoadJob(ctx, id)
+}
+
+// Cancel requests cancellation of a queued or running job.
+//
+// Queued job is marked canceled when a worker takes it, running job is marked canceled
+// when its interaction returns, unless it has finished already.
+// It fails with status.FailedPrecondition if job is already finished.
+func (r *JobRunner) Cancel(ctx context.Context, id string) error {
+	r.mu.Lock()
+	cancel, found := r.cancels[id]
+	r.mu.Unlock()
+
+	if found {
+		// Job state is only updated by worker to avoid overwriting finished job.
+		cancel()
+
+		return nil
+	}
+
+	job, err := r.Store.LoadJob(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("job %s is %s: %w", id, job.Status, status.FailedPrecondition)
+}
+
+// Close stops accepting new jobs and waits for queued jobs to finish.
+func (r *JobRunner) Close() {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+}
+
+func (r *JobRunner) work() {
+	defer r.wg.Done()
+
+	for task := range r.queue {
+		r.run(task)
+	}
+}
+
+func (r *JobRunner) run(task jobTask) {
+	job := task.job
+
+	// Job state is saved even if job context is canceled.
+	storeCtx := detachedContext{parent: task.ctx}
+
+	defer func() {
+		r.mu.Lock()
+		cancel := r.cancels[task.job.ID]
+		delete(r.cancels, task.job.ID)
+		r.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+	}()
+
+	if task.ctx.Err() != nil {
+		job.Status = JobCanceled
+		job.StatusCode = status.Canceled
+		job.Error = status.Canceled.Error()
+
+		r.save(storeCtx, job)
+
+		return
+	}
+
+	job.Status = JobRunning
+	r.save(storeCtx, job)
+
+	var (
+		mu     sync.Mutex
+		closed bool
+	)
+
+	ctx := WithProgress(task.ctx, ProgressFunc(func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Progress can be reported late from a goroutine of interactor, it must not overwrite final state.
+		if closed {
+			return
+		}
+
+		job.Progress = p
+		r.save(storeCtx, job)
+	}))
+
+	if o, ok := task.output.(OutputWithProgress); ok {
+		o.SetProgressReporter(ProgressFromContext(ctx))
+	}
+
+	err := r.interact(ctx, task)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	closed = true
+	job = r.finish(task, job, err)
+
+	r.save(storeCtx, job)
+}
+
+// interact invokes job interactor, panic is returned as status.Internal error.
+func (r *JobRunner) interact(ctx context.Context, task jobTask) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v: %w", rec, status.Internal)
+		}
+	}()
+
+	return task.u.Interact(ctx, task.input, task.output)
+}
+
+func (r *JobRunner) finish(task jobTask, job Job, err error) Job {
+	if err == nil {
+		job.Status = JobSucceeded
+		job.Output = task.output
+
+		if job.Progress.Total == 0 {
+			job.Progress = Progress{Done: 1, Total: 1}
+		}
+
+		return job
+	}
+
+	if task.ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		job.Status = JobCanceled
+		job.StatusCode = status.Canceled
+		job.Error = status.Canceled.Error()
+
+		return job
+	}
+
+	job.Status = JobFailed
+	job.Error = err.Error()
+
+	job.StatusCode = StatusOf(err)
+	if job.StatusCode == 0 {
+		job.StatusCode = status.Unknown
+	}
+
+	return job
+}
+
+func (r *JobRunner) save(ctx context.Context, job Job) {
+	if err := r.Store.SaveJob(ctx, job); err != nil && r.OnStoreError != nil {
+		r.OnStoreError(ctx, job, err)
+	}
+}
+
+// AsyncOutput is an output of asynchronous use case.
+type AsyncOutput struct {
+	JobID string `json:"jobId"`
+}
+
+// NewAsync creates use case interactor that submits interaction of u to job runner and returns job ID.
+//
+// Input is shallowly copied into a fresh value of input port before submitting.
+// Name, title, description and tags are taken from u, input port is the same as in u.
+// Job state can be queried from runner with job ID.
+func NewAsync(u Interactor, r *JobRunner, options ...func(i *IOInteractor)) IOInteractor {
+	a := IOInteractor{}
+	a.Input = inputPort(u)
+	a.Output = new(AsyncOutput)
+	a.Interactor = Interact(func(ctx context.Context, input, output interface{}) error {
+		out, ok := output.(*AsyncOutput)
+		if !ok {
+			return fmt.Errorf("%w of output: %T, expected: %T", ErrInvalidType, output, out)
+		}
+
+		// Transport may reuse input after interaction returns, so job gets its own copy.
+		in, err := copyInput(u, input)
+		if err != nil {
+			return err
+		}
+
+		id, err := r.Submit(ctx, u, in)
+		if err != nil {
+			return err
+		}
+
+		out.JobID = id
+
+		return nil
+	})
+
+	var (
+		withName        HasName
+		withTitle       HasTitle
+		withDescription HasDescription
+		withTags        HasTags
+	)
+
+	if As(u, &withName) && withName.Name() != "" {
+		a.name = withName.Name() + "/async"
+	}
+
+	if As(u, &withTitle) && withTitle.Title() != "" {
+		a.title = withTitle.Title() + " (async)"
+	}
+
+	if As(u, &withDescription) {
+		a.description = withDescription.Description()
+	}
+
+	if As(u, &withTags) {
+		a.tags = withTags.Tags()
+	}
+
+	a.expectedErrors = []error{status.ResourceExhausted, status.Unavailable}
+
+	for _, o := range options {
+		o(&a)
+	}
+
+	return a
+}
+
+// copyInput makes shallow copy of pointer input into a fresh value of input port.
+func copyInput(u Interactor, input interface{}) (interface{}, error) {
+	fresh := NewInput(u)
+	if fresh == nil || input == nil {
+		return input, nil
+	}
+
+	fv := reflect.ValueOf(fresh)
+	if fv.Kind() != reflect.Ptr {
+		// Value input is already a copy.
+		return input, nil
+	}
+
+	iv := reflect.ValueOf(input)
+	if iv.Type() != fv.Type() {
+		return nil, fmt.Errorf("%w of input: %T, expected: %T", ErrInvalidType, input, fresh)
+	}
+
+	if !iv.IsNil() {
+		fv.Elem().Set(iv.Elem())
+	}
+
+	return fresh, nil
+}
+
+// detachedContext keeps values of parent context without its cancellation.
+type detachedContext struct {
+	parent context.Context //nolint:containedctx // Parent context provides values.
+}
+
+func (detachedContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
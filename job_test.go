@@ -0,0 +1,299 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+func waitJob(t *testing.T, r *usecase.JobRunner, id string) usecase.Job {
+	t.Helper()
+
+	for i := 0; i < 1000; i++ {
+		job, err := r.Job(context.Background(), id)
+		require.NoError(t, err)
+
+		if job.Finished() {
+			return job
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("job %s is not finished", id)
+
+	return usecase.Job{}
+}
+
+type userKey struct{}
+
+func TestJobRunner_Submit(t *testing.T) {
+	r := usecase.NewJobRunner(2)
+	defer r.Close()
+
+	u := usecase.NewIOI(new(int), new(string), func(ctx context.Context, input, output interface{}) error {
+		if *input.(*int) == 0 {
+			return status.Wrap(errors.New("empty"), status.InvalidArgument)
+		}
+
+		*output.(*string) = ctx.Value(userKey{}).(string)
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), userKey{}, "jane"))
+
+	in := 1
+	id, err := r.Submit(ctx, u, &in)
+	require.NoError(t, err)
+
+	// Cancellation of request context does not affect job.
+	cancel()
+
+	job := waitJob(t, r, id)
+	assert.Equal(t, usecase.JobSucceeded, job.Status)
+	assert.Equal(t, status.OK, job.StatusCode)
+	assert.Equal(t, "swaggest/usecase_test.TestJobRunner_Submit", job.Name)
+	assert.Equal(t, usecase.Progress{Done: 1, Total: 1}, job.Progress)
+
+	out := "jane"
+	assert.Equal(t, &out, job.Output)
+
+	in2 := 0
+	id, err = r.Submit(context.Background(), u, &in2)
+	require.NoError(t, err)
+
+	job = waitJob(t, r, id)
+	assert.Equal(t, usecase.JobFailed, job.Status)
+	assert.Equal(t, status.InvalidArgument, job.StatusCode)
+	assert.Equal(t, "invalid argument: empty", job.Error)
+	assert.Nil(t, job.Output)
+
+	err = r.Cancel(context.Background(), id)
+	assert.True(t, errors.Is(err, status.FailedPrecondition))
+
+	_, err = r.Job(context.Background(), "missing")
+	assert.True(t, errors.Is(err, status.NotFound))
+
+	err = r.Cancel(context.Background(), "missing")
+	assert.True(t, errors.Is(err, status.NotFound))
+}
+
+func TestJobRunner_Cancel(t *testing.T) {
+	store := &usecase.InMemoryJobStore{}
+	r := usecase.NewJobRunner(1, func(r *usecase.JobRunner) {
+		r.Store = store
+		r.QueueSize = 1
+	})
+
+	started := make(chan struct{})
+
+	slow := usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	ctx := context.Background()
+
+	running, err := r.Submit(ctx, slow, nil)
+	require.NoError(t, err)
+
+	<-started
+
+	queued, err := r.Submit(ctx, slow, nil)
+	require.NoError(t, err)
+
+	// Queue is full.
+	_, err = r.Submit(ctx, slow, nil)
+	assert.True(t, errors.Is(err, status.ResourceExhausted))
+
+	require.NoError(t, r.Cancel(ctx, queued))
+
+	// Queued job is marked canceled by worker.
+	job, err := store.LoadJob(ctx, queued)
+	require.NoError(t, err)
+	assert.Equal(t, usecase.JobQueued, job.Status)
+
+	require.NoError(t, r.Cancel(ctx, running))
+
+	job = waitJob(t, r, running)
+	assert.Equal(t, usecase.JobCanceled, job.Status)
+	assert.Equal(t, status.Canceled, job.StatusCode)
+
+	r.Close()
+
+	job = waitJob(t, r, queued)
+	assert.Equal(t, usecase.JobCanceled, job.Status)
+
+	_, err = r.Submit(ctx, slow, nil)
+	assert.True(t, errors.Is(err, status.Unavailable))
+}
+
+func TestJobRunner_Cancel_finished(t *testing.T) {
+	r := usecase.NewJobRunner(4)
+	defer r.Close()
+
+	u := usecase.NewIOI(nil, new(int), func(ctx context.Context, input, output interface{}) error {
+		*output.(*int) = 1
+
+		return nil
+	})
+
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		id, err := r.Submit(ctx, u, nil)
+		require.NoError(t, err)
+
+		err = r.Cancel(ctx, id)
+		if err != nil {
+			assert.True(t, errors.Is(err, status.FailedPrecondition))
+		}
+
+		job := waitJob(t, r, id)
+
+		// Cancellation must not overwrite finished job.
+		if job.Status == usecase.JobSucceeded {
+			assert.Equal(t, 1, *job.Output.(*int))
+		} else {
+			assert.Equal(t, usecase.JobCanceled, job.Status)
+			assert.Nil(t, job.Output)
+		}
+	}
+}
+
+func TestNewAsync(t *testing.T) {
+	r := usecase.NewJobRunner(1)
+	defer r.Close()
+
+	u := usecase.NewIOI(new(int), new(int), func(ctx context.Context, input, output interface{}) error {
+		*output.(*int) = *input.(*int) * 2
+
+		return nil
+	})
+	u.SetTags("math")
+	u.SetDescription("Doubles value.")
+
+	a := usecase.NewAsync(u, r)
+	assert.Equal(t, "swaggest/usecase_test.TestNewAsync/async", a.Name())
+	assert.Equal(t, "Test New Async (async)", a.Title())
+	assert.Equal(t, "Doubles value.", a.Description())
+	assert.Equal(t, []string{"math"}, a.Tags())
+	assert.Equal(t, new(int), a.InputPort())
+	assert.Equal(t, new(usecase.AsyncOutput), a.OutputPort())
+
+	in := 21
+	out := usecase.AsyncOutput{}
+
+	require.NoError(t, a.Interact(context.Background(), &in, &out))
+	assert.NotEmpty(t, out.JobID)
+
+	job := waitJob(t, r, out.JobID)
+	assert.Equal(t, usecase.JobSucceeded, job.Status)
+
+	res := 42
+	assert.Equal(t, &res, job.Output)
+
+	assert.True(t, errors.Is(a.Interact(context.Background(), &in, nil), usecase.ErrInvalidType))
+}
+
+func TestNewAsync_inputReuse(t *testing.T) {
+	r := usecase.NewJobRunner(1)
+	defer r.Close()
+
+	release := make(chan struct{})
+
+	u := usecase.NewIOI(new(int), new(int), func(ctx context.Context, input, output interface{}) error {
+		<-release
+
+		*output.(*int) = *input.(*int) * 2
+
+		return nil
+	})
+
+	a := usecase.NewAsync(u, r)
+	p := usecase.NewPortPool(a)
+
+	in := p.GetInput().(*int)
+	*in = 21
+	out := usecase.AsyncOutput{}
+
+	require.NoError(t, a.Interact(context.Background(), in, &out))
+
+	// Transport releases input while job is still pending.
+	p.PutInput(in)
+	close(release)
+
+	job := waitJob(t, r, out.JobID)
+	assert.Equal(t, usecase.JobSucceeded, job.Status)
+	assert.Equal(t, 42, *job.Output.(*int))
+
+	err := a.Interact(context.Background(), "21", &out)
+	assert.True(t, errors.Is(err, usecase.ErrInvalidType))
+}
+
+func TestJobRunner_Cancel_lateProgress(t *testing.T) {
+	r := usecase.NewJobRunner(1)
+	defer r.Close()
+
+	started := make(chan struct{})
+	reported := make(chan struct{})
+
+	u := usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		report := usecase.ProgressFromContext(ctx)
+
+		go func() {
+			defer close(reported)
+
+			<-ctx.Done()
+
+			for i := int64(1); i <= 1000; i++ {
+				report.Report(i, 100, "late")
+			}
+		}()
+
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	ctx := context.Background()
+
+	id, err := r.Submit(ctx, u, nil)
+	require.NoError(t, err)
+
+	<-started
+	require.NoError(t, r.Cancel(ctx, id))
+
+	<-reported
+
+	// Progress reported after cancellation must not overwrite final state.
+	job := waitJob(t, r, id)
+	assert.Equal(t, usecase.JobCanceled, job.Status)
+}
+
+func TestJobRunner_Submit_panic(t *testing.T) {
+	r := usecase.NewJobRunner(1)
+	defer r.Close()
+
+	u := usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		panic("boom")
+	})
+
+	id, err := r.Submit(context.Background(), u, nil)
+	require.NoError(t, err)
+
+	job := waitJob(t, r, id)
+	assert.Equal(t, usecase.JobFailed, job.Status)
+	assert.Equal(t, status.Internal, job.StatusCode)
+	assert.Equal(t, "panic: boom: internal", job.Error)
+}
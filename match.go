@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/swaggest/usecase/status"
+)
+
+// ErrorMatcher matches errors by status code, application code and context keys.
+//
+// Zero fields are not checked. ErrorMatcher can be listed in expected errors of use case.
+type ErrorMatcher struct {
+	StatusCode  status.Code
+	AppCode     int
+	ContextKeys []string
+}
+
+// Error describes matching criteria.
+func (m ErrorMatcher) Error() string {
+	var parts []string
+
+	if m.StatusCode != 0 {
+		parts = append(parts, "status "+m.StatusCode.String())
+	}
+
+	if m.AppCode != 0 {
+		parts = append(parts, fmt.Sprintf("app code %d", m.AppCode))
+	}
+
+	if len(m.ContextKeys) > 0 {
+		parts = append(parts, "context keys "+strings.Join(m.ContextKeys, ", "))
+	}
+
+	if len(parts) == 0 {
+		return "any error"
+	}
+
+	return "error with " + strings.Join(parts, ", ")
+}
+
+// Status returns expected status code.
+func (m ErrorMatcher) Status() status.Code {
+	return m.StatusCode
+}
+
+// AppErrCode returns expected application level error code.
+func (m ErrorMatcher) AppErrCode() int {
+	return m.AppCode
+}
+
+// Match checks if error chain satisfies matching criteria.
+func (m ErrorMatcher) Match(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if m.StatusCode != 0 && StatusOf(err) != m.StatusCode {
+		return false
+	}
+
+	if m.AppCode != 0 {
+		var ae interface {
+			AppErrCode() int
+		}
+
+		if !errors.As(err, &ae) || ae.AppErrCode() != m.AppCode {
+			return false
+		}
+	}
+
+	if len(m.ContextKeys) > 0 {
+		var fe interface {
+			Fields() map[string]interface{}
+		}
+
+		if !errors.As(err, &fe) {
+			return false
+		}
+
+		fields := fe.Fields()
+
+		for _, k := range m.ContextKeys {
+			if _, found := fields[k]; !found {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// IsExpected checks if error matches any of expected errors, e.g. from HasExpectedErrors.
+//
+// Expected error matches if it has Match(error) bool method that returns true, or if errors.Is
+// reports a match, or if it has same status code and no application code.
+func IsExpected(err error, expected ...error) bool {
+	if err == nil {
+		return false
+	}
+
+	code := StatusOf(err)
+
+	for _, e := range expected {
+		if m, ok := e.(interface{ Match(err error) bool }); ok {
+			if m.Match(err) {
+				return true
+			}
+
+			continue
+		}
+
+		if errors.Is(err, e) {
+			return true
+		}
+
+		if ae, ok := e.(interface{ AppErrCode() int }); ok && ae.AppErrCode() != 0 {
+			continue
+		}
+
+		if se, ok := e.(interface{ Status() status.Code }); ok && se.Status() != 0 && se.Status() == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusOf returns the first non-zero status code of error chain or zero.
+//
+// It is the status code that ErrorMatcher and IsExpected check.
+func StatusOf(err error) status.Code {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if se, ok := err.(interface{ Status() status.Code }); ok && se.Status() != 0 {
+			return se.Status()
+		}
+	}
+
+	return 0
+}
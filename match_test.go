@@ -0,0 +1,60 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+func TestErrorMatcher_Match(t *testing.T) {
+	err := fmt.Errorf("loading order: %w", usecase.Error{
+		AppCode:    1001,
+		StatusCode: status.NotFound,
+		Value:      errors.New("order not found"),
+		Context:    map[string]interface{}{"orderID": 123},
+	})
+
+	m := usecase.ErrorMatcher{}
+	assert.EqualError(t, m, "any error")
+	assert.True(t, m.Match(err))
+	assert.False(t, m.Match(nil))
+
+	m = usecase.ErrorMatcher{StatusCode: status.NotFound, AppCode: 1001, ContextKeys: []string{"orderID"}}
+	assert.EqualError(t, m, "error with status NOT_FOUND, app code 1001, context keys orderID")
+	assert.Equal(t, status.NotFound, m.Status())
+	assert.Equal(t, 1001, m.AppErrCode())
+	assert.True(t, m.Match(err))
+
+	assert.False(t, usecase.ErrorMatcher{StatusCode: status.Internal}.Match(err))
+	assert.False(t, usecase.ErrorMatcher{AppCode: 1002}.Match(err))
+	assert.False(t, usecase.ErrorMatcher{ContextKeys: []string{"userID"}}.Match(err))
+	assert.False(t, usecase.ErrorMatcher{AppCode: 1001}.Match(status.NotFound))
+	assert.False(t, usecase.ErrorMatcher{ContextKeys: []string{"orderID"}}.Match(status.NotFound))
+	assert.True(t, usecase.ErrorMatcher{StatusCode: status.NotFound}.Match(status.Wrap(errors.New("failed"), status.NotFound)))
+}
+
+func TestIsExpected(t *testing.T) {
+	appErr := usecase.Error{
+		AppCode:    1001,
+		StatusCode: status.NotFound,
+		Value:      errors.New("order not found"),
+	}
+	err := fmt.Errorf("loading order: %w", appErr)
+
+	assert.False(t, usecase.IsExpected(nil, status.NotFound))
+	assert.False(t, usecase.IsExpected(err))
+
+	assert.True(t, usecase.IsExpected(err, status.InvalidArgument, status.NotFound))
+	assert.True(t, usecase.IsExpected(err, status.WithDescription(status.NotFound, "Order not found.")))
+	assert.True(t, usecase.IsExpected(err, usecase.Error{AppCode: 1001}))
+	assert.True(t, usecase.IsExpected(err, usecase.ErrorMatcher{AppCode: 1001}))
+
+	assert.False(t, usecase.IsExpected(err, usecase.Error{AppCode: 1002, StatusCode: status.NotFound}))
+	assert.False(t, usecase.IsExpected(err, usecase.ErrorMatcher{AppCode: 1002}))
+	assert.False(t, usecase.IsExpected(err, status.WithDescription(status.Internal, "Failure.")))
+	assert.False(t, usecase.IsExpected(errors.New("failed"), usecase.Error{Value: errors.New("failed")}))
+}
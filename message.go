@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/usecase/status"
+)
+
+type localeCtxKey struct{}
+
+// WithLocale returns context with locale to select localized messages, e.g. "de-CH".
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeCtxKey{}, locale)
+}
+
+// LocaleFromContext returns locale stored in context or empty string.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeCtxKey{}).(string) //nolint:errcheck // Empty locale is a valid default.
+
+	return locale
+}
+
+// MessageCatalogue keeps localized message templates for status codes and application errors.
+//
+// Templates may have placeholders like {name} that are filled from Error.Context.
+// Zero value is ready to use.
+type MessageCatalogue struct {
+	mu     sync.RWMutex
+	status map[string]map[status.Code]string
+	app    map[string]map[int]string
+}
+
+// SetStatusMessage sets message template for status code in locale.
+func (m *MessageCatalogue) SetStatusMessage(locale string, code status.Code, template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.status == nil {
+		m.status = make(map[string]map[status.Code]string)
+	}
+
+	locale = normalizeLocale(locale)
+
+	if m.status[locale] == nil {
+		m.status[locale] = make(map[status.Code]string)
+	}
+
+	m.status[locale][code] = template
+}
+
+// SetAppMessage sets message template for application error code in locale.
+func (m *MessageCatalogue) SetAppMessage(locale string, appCode int, template string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.app == nil {
+		m.app = make(map[string]map[int]string)
+	}
+
+	locale = normalizeLocale(locale)
+
+	if m.app[locale] == nil {
+		m.app[locale] = make(map[int]string)
+	}
+
+	m.app[locale][appCode] = template
+}
+
+// StatusMessage returns localized message of status code.
+//
+// Default English message is returned if there is no translation for locale from context.
+func (m *MessageCatalogue) StatusMessage(ctx context.Context, code status.Code) string {
+	if tpl, found := m.statusTemplate(LocaleFromContext(ctx), code); found {
+		return formatMessage(tpl, nil)
+	}
+
+	return code.Error()
+}
+
+// Message returns localized message of an error.
+//
+// Application error code of Error in chain takes precedence over status code.
+// Error message is returned as is if there is no translation for locale from context.
+func (m *MessageCatalogue) Message(ctx context.Context, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	locale := LocaleFromContext(ctx)
+	if locale == "" {
+		return err.Error()
+	}
+
+	var (
+		e      Error
+		params map[string]interface{}
+	)
+
+	if errors.As(err, &e) {
+		params = e.Context
+
+		if tpl, found := m.appTemplate(locale, e.AppCode); e.AppCode != 0 && found {
+			return formatMessage(tpl, params)
+		}
+	}
+
+	if code := StatusOf(err); code != 0 {
+		if tpl, found := m.statusTemplate(locale, code); found {
+			return formatMessage(tpl, params)
+		}
+	}
+
+	return err.Error()
+}
+
+func (m *MessageCatalogue) statusTemplate(locale string, code status.Code) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, l := range localeFallbacks(locale) {
+		if tpl, found := m.status[l][code]; found {
+			return tpl, true
+		}
+	}
+
+	return "", false
+}
+
+func (m *MessageCatalogue) appTemplate(locale string, appCode int) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, l := range localeFallbacks(locale) {
+		if tpl, found := m.app[l][appCode]; found {
+			return tpl, true
+		}
+	}
+
+	return "", false
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// localeFallbacks returns locale and its base language, e.g. "de-ch" and "de" for "de_CH".
+func localeFallbacks(locale string) []string {
+	if locale == "" {
+		return nil
+	}
+
+	locale = normalizeLocale(locale)
+
+	if pos := strings.IndexByte(locale, '-'); pos > 0 {
+		return []string{locale, locale[:pos]}
+	}
+
+	return []string{locale}
+}
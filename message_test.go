@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+func TestLocaleFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", usecase.LocaleFromContext(ctx))
+	assert.Equal(t, "de-CH", usecase.LocaleFromContext(usecase.WithLocale(ctx, "de-CH")))
+}
+
+func TestMessageCatalogue_Message(t *testing.T) {
+	m := usecase.MessageCatalogue{}
+	m.SetStatusMessage("de", status.NotFound, "nicht gefunden")
+	m.SetStatusMessage("de_CH", status.InvalidArgument, "ungültiges Argument {field}")
+	m.SetAppMessage("de", 1001, "Bestellung {id} nicht gefunden")
+
+	appErr := usecase.Error{
+		AppCode:    1001,
+		StatusCode: status.NotFound,
+		Value:      errors.New("order 123 not found"),
+		Context:    map[string]interface{}{"id": 123},
+	}
+
+	ctx := context.Background()
+	de := usecase.WithLocale(ctx, "de")
+	deCH := usecase.WithLocale(ctx, "de-CH")
+	fr := usecase.WithLocale(ctx, "fr")
+
+	assert.Equal(t, "", m.Message(de, nil))
+
+	// Default messages.
+	assert.Equal(t, "not found: order 123 not found", m.Message(ctx, appErr))
+	assert.Equal(t, "not found: order 123 not found", m.Message(fr, appErr))
+	assert.Equal(t, "invalid argument", m.Message(ctx, status.InvalidArgument))
+	assert.Equal(t, "invalid argument", m.StatusMessage(ctx, status.InvalidArgument))
+	assert.Equal(t, "invalid argument", m.StatusMessage(de, status.InvalidArgument))
+
+	// Application code takes precedence.
+	assert.Equal(t, "Bestellung 123 nicht gefunden", m.Message(de, appErr))
+	assert.Equal(t, "Bestellung 123 nicht gefunden", m.Message(deCH, fmt.Errorf("wrapped: %w", appErr)))
+
+	// Status code is used if there is no application message.
+	appErr.AppCode = 1002
+	assert.Equal(t, "nicht gefunden", m.Message(deCH, appErr))
+	assert.Equal(t, "nicht gefunden", m.Message(de, status.Wrap(errors.New("failed"), status.NotFound)))
+	assert.Equal(t, "nicht gefunden", m.StatusMessage(deCH, status.NotFound))
+
+	// Region specific message.
+	invalid := usecase.Error{StatusCode: status.InvalidArgument, Context: map[string]interface{}{"field": "name"}}
+	assert.Equal(t, "ungültiges Argument name", m.Message(deCH, invalid))
+	assert.Equal(t, "invalid argument", m.Message(de, invalid))
+
+	// Unknown error is not translated.
+	assert.Equal(t, "failed", m.Message(de, errors.New("failed")))
+}
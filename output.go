@@ -1,6 +1,11 @@
 package usecase
 
-import "io"
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+)
 
 // OutputWithWriter defines output with streaming writer.
 type OutputWithWriter interface {
@@ -17,6 +22,15 @@ func (o *OutputWithEmbeddedWriter) SetWriter(w io.Writer) {
 	o.Writer = w
 }
 
+// OutputWithItemStream defines output with stream of typed items, see OutputWithStream.
+type OutputWithItemStream interface {
+	// StreamItem returns sample of stream item value.
+	StreamItem() interface{}
+
+	// SetItemSender sets receiver of stream items.
+	SetItemSender(send func(ctx context.Context, item interface{}) error)
+}
+
 // OutputWithNoContent is embeddable structure to provide conditional output discard state.
 type OutputWithNoContent struct {
 	disabled bool
@@ -31,3 +45,80 @@ func (o *OutputWithNoContent) SetNoContent(enabled bool) {
 func (o OutputWithNoContent) NoContent() bool {
 	return !o.disabled
 }
+
+// HasOutputMetadata declares transport-neutral output metadata, e.g. caching hints or resource location.
+//
+// Transports can map metadata to HTTP headers, gRPC metadata or message properties.
+type HasOutputMetadata interface {
+	OutputMetadata() map[string]string
+}
+
+// Output metadata keys.
+const (
+	MetadataETag         = "ETag"
+	MetadataLastModified = "Last-Modified"
+	MetadataLocation     = "Location"
+	MetadataCacheControl = "Cache-Control"
+)
+
+// OutputWithMetadata is embeddable structure to provide output metadata.
+type OutputWithMetadata struct {
+	metadata map[string]string
+}
+
+var _ HasOutputMetadata = OutputWithMetadata{}
+
+// OutputMetadata implements HasOutputMetadata.
+func (o OutputWithMetadata) OutputMetadata() map[string]string {
+	return o.metadata
+}
+
+// Metadata returns metadata value by key.
+func (o OutputWithMetadata) Metadata(key string) string {
+	return o.metadata[key]
+}
+
+// SetMetadata sets metadata value, empty value removes key.
+func (o *OutputWithMetadata) SetMetadata(key, value string) {
+	if value == "" {
+		delete(o.metadata, key)
+
+		return
+	}
+
+	if o.metadata == nil {
+		o.metadata = make(map[string]string)
+	}
+
+	o.metadata[key] = value
+}
+
+// SetETag sets entity tag of resource, tag is quoted if it is not.
+func (o *OutputWithMetadata) SetETag(etag string) {
+	if etag != "" && !strings.HasSuffix(etag, `"`) {
+		etag = `"` + etag + `"`
+	}
+
+	o.SetMetadata(MetadataETag, etag)
+}
+
+// SetLastModified sets modification time of resource, zero time removes value.
+func (o *OutputWithMetadata) SetLastModified(t time.Time) {
+	if t.IsZero() {
+		o.SetMetadata(MetadataLastModified, "")
+
+		return
+	}
+
+	o.SetMetadata(MetadataLastModified, t.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT"))
+}
+
+// SetLocation sets location of created or moved resource.
+func (o *OutputWithMetadata) SetLocation(location string) {
+	o.SetMetadata(MetadataLocation, location)
+}
+
+// SetCacheControl sets caching directives, e.g. "max-age=60".
+func (o *OutputWithMetadata) SetCacheControl(directives string) {
+	o.SetMetadata(MetadataCacheControl, directives)
+}
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,3 +27,40 @@ func TestOutputWithNoContent_NoContent(t *testing.T) {
 	o.SetNoContent(false)
 	assert.False(t, o.NoContent())
 }
+
+func TestOutputWithMetadata(t *testing.T) {
+	type output struct {
+		usecase.OutputWithMetadata
+		Name string `json:"name"`
+	}
+
+	out := output{}
+	assert.Nil(t, out.OutputMetadata())
+
+	out.SetETag("abc")
+	out.SetLastModified(time.Date(2022, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600)))
+	out.SetLocation("/orders/123")
+	out.SetCacheControl("max-age=60")
+	out.SetMetadata("X-Request-Id", "req1")
+
+	var m usecase.HasOutputMetadata = out
+
+	assert.Equal(t, map[string]string{
+		"ETag":          `"abc"`,
+		"Last-Modified": "Fri, 04 Mar 2022 04:06:07 GMT",
+		"Location":      "/orders/123",
+		"Cache-Control": "max-age=60",
+		"X-Request-Id":  "req1",
+	}, m.OutputMetadata())
+
+	out.SetETag(`W/"weak"`)
+	assert.Equal(t, `W/"weak"`, out.Metadata(usecase.MetadataETag))
+
+	out.SetLastModified(time.Time{})
+	out.SetMetadata("X-Request-Id", "")
+	out.SetETag("")
+	assert.Equal(t, map[string]string{
+		"Location":      "/orders/123",
+		"Cache-Control": "max-age=60",
+	}, out.OutputMetadata())
+}
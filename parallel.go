@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/usecase/status"
+)
+
+// ParallelBranch is a child interactor of Parallel.
+type ParallelBranch struct {
+	Interactor
+
+	// Input converts parallel input into branch input, optional.
+	//
+	// Without Input, parallel input is passed as is.
+	Input func(ctx context.Context, input interface{}) (interface{}, error)
+
+	// Output returns branch output destination within parallel output,
+	// e.g. pointer to a field of composite output structure, optional.
+	//
+	// Without Output, branch writes to a fresh value of its output port that is discarded.
+	Output func(output interface{}) interface{}
+}
+
+// Parallel is an Interactor that runs branches concurrently and merges their outputs.
+//
+// Branches share a context that is canceled when Interact returns,
+// or when any branch fails in fail-fast mode.
+type Parallel struct {
+	Branches []ParallelBranch
+
+	// Limit is a maximum number of concurrently running branches, zero means no limit.
+	Limit int
+
+	// CollectAll disables fail-fast mode to wait for all branches and collect all errors.
+	CollectAll bool
+}
+
+// NewParallel creates use case interactor with input and output ports from Parallel.
+//
+// It pre-fills name and title with caller function.
+func NewParallel(input, output interface{}, p Parallel, options ...func(i *IOInteractor)) IOInteractor {
+	u := IOInteractor{}
+	u.Input = input
+	u.Output = output
+	u.Interactor = p
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u)
+	}
+
+	return u
+}
+
+// Interact implements Interactor.
+//
+// Branch errors are annotated with branch name and returned as ParallelError.
+func (p Parallel) Interact(ctx context.Context, input, output interface{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+		failed  bool
+		started int
+		sem     chan struct{}
+	)
+
+	if p.Limit > 0 {
+		sem = make(chan struct{}, p.Limit)
+	}
+
+	for idx, b := range p.Branches {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+
+		mu.Lock()
+		stop := failed && !p.CollectAll
+		mu.Unlock()
+
+		if stop || ctx.Err() != nil {
+			break
+		}
+
+		started++
+
+		wg.Add(1)
+
+		go func(idx int, b ParallelBranch) {
+			defer func() {
+				if sem != nil {
+					<-sem
+				}
+
+				wg.Done()
+			}()
+
+			err := b.interact(ctx, input, output)
+			if err == nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			// Cancellation caused by fail-fast mode is not an error of a branch.
+			if failed && !p.CollectAll && errors.Is(err, context.Canceled) {
+				return
+			}
+
+			failed = true
+
+			errs = append(errs, fmt.Errorf("%s: %w", stepName(b.Interactor, idx), err))
+
+			if !p.CollectAll {
+				cancel()
+			}
+		}(idx, b)
+	}
+
+	wg.Wait()
+
+	if len(errs) != 0 {
+		return ParallelError{Errors: errs}
+	}
+
+	// Parent context can be done before all branches are started.
+	if started < len(p.Branches) {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return status.Wrap(ctx.Err(), status.DeadlineExceeded)
+		}
+
+		return status.Wrap(ctx.Err(), status.Canceled)
+	}
+
+	return nil
+}
+
+func (b ParallelBranch) interact(ctx context.Context, input, output interface{}) (err error) {
+	in := input
+	if b.Input != nil {
+		if in, err = b.Input(ctx, input); err != nil {
+			return err
+		}
+	}
+
+	var out interface{}
+	if b.Output != nil {
+		out = b.Output(output)
+	} else {
+		out = NewOutput(b.Interactor)
+	}
+
+	return b.Interactor.Interact(ctx, in, out)
+}
+
+// ParallelError aggregates errors of parallel branches.
+type ParallelError struct {
+	Errors []error
+}
+
+// Error returns joined messages of branch errors.
+func (e ParallelError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Status returns status code shared by all branch errors, or status.Unknown if codes are different.
+func (e ParallelError) Status() status.Code {
+	var code status.Code
+
+	for i, err := range e.Errors {
+		c := StatusOf(err)
+		if c == 0 {
+			c = status.Unknown
+		}
+
+		if i > 0 && c != code {
+			return status.Unknown
+		}
+
+		code = c
+	}
+
+	return code
+}
+
+// Is implements interface for errors.Is, it reports a match of any branch error.
+func (e ParallelError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unwrap returns the first branch error.
+func (e ParallelError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e.Errors[0]
+}
@@ -0,0 +1,71 @@
+//go:build go1.18
+// +build go1.18
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// NewBranch creates parallel branch of generic interactor.
+//
+// Input mapper converts parallel input into branch input, if it is nil parallel input is passed as is,
+// and NewBranch panics if types of parallel and branch inputs are different.
+// Output selector returns destination of branch output within parallel output, e.g. pointer to a field.
+func NewBranch[i, o, bi, bo any](u IOInteractorOf[bi, bo], input func(i) bi, output func(*o) *bo) ParallelBranch {
+	if input == nil {
+		it, bit := reflect.TypeOf((*i)(nil)).Elem(), reflect.TypeOf((*bi)(nil)).Elem()
+		if it != bit {
+			panic(fmt.Sprintf("input mapper is required to pass %s as %s", it, bit))
+		}
+	}
+
+	b := ParallelBranch{
+		Interactor: u,
+	}
+
+	b.Input = func(ctx context.Context, in interface{}) (interface{}, error) {
+		v, ok := in.(i)
+		if !ok {
+			return nil, fmt.Errorf("%w of input: %T, expected: %T", ErrInvalidType, in, *new(i))
+		}
+
+		if input == nil {
+			return v, nil
+		}
+
+		return input(v), nil
+	}
+
+	if output != nil {
+		b.Output = func(out interface{}) interface{} {
+			if v, ok := out.(*o); ok {
+				return output(v)
+			}
+
+			return nil
+		}
+	}
+
+	return b
+}
+
+// NewParallelOf creates generic use case interactor from Parallel.
+//
+// It pre-fills name and title with caller function.
+func NewParallelOf[i, o any](p Parallel, options ...func(i *IOInteractor)) IOInteractorOf[i, o] {
+	u := newInteractorOf(func(ctx context.Context, input i, output *o) error {
+		return p.Interact(ctx, input, output)
+	})
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u.IOInteractor)
+	}
+
+	return u
+}
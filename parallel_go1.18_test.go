@@ -0,0 +1,50 @@
+//go:build go1.18
+// +build go1.18
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+)
+
+func TestNewParallelOf(t *testing.T) {
+	profile := usecase.NewInteractor(func(ctx context.Context, input string, output *string) error {
+		*output = "user " + input
+
+		return nil
+	})
+
+	orders := usecase.NewInteractor(func(ctx context.Context, input int, output *[]int) error {
+		*output = []int{input, input + 1}
+
+		return nil
+	})
+
+	u := usecase.NewParallelOf[int, dashboard](usecase.Parallel{
+		Branches: []usecase.ParallelBranch{
+			usecase.NewBranch(profile, strconv.Itoa, func(o *dashboard) *string { return &o.Profile }),
+			usecase.NewBranch[int](orders, nil, func(o *dashboard) *[]int { return &o.Orders }),
+		},
+	})
+
+	assert.Equal(t, "swaggest/usecase_test.TestNewParallelOf", u.Name())
+
+	out := dashboard{}
+
+	require.NoError(t, u.Invoke(context.Background(), 1, &out))
+	assert.Equal(t, dashboard{Profile: "user 1", Orders: []int{1, 2}}, out)
+
+	err := u.Interact(context.Background(), "1", &out)
+	assert.True(t, errors.Is(err, usecase.ErrInvalidType))
+
+	assert.PanicsWithValue(t, "input mapper is required to pass int as string", func() {
+		usecase.NewBranch[int](profile, nil, func(o *dashboard) *string { return &o.Profile })
+	})
+}
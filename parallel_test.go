@@ -0,0 +1,182 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type dashboard struct {
+	Profile string
+	Orders  []int
+}
+
+func TestNewParallel(t *testing.T) {
+	profile := usecase.NewIOI(new(int), new(string), func(ctx context.Context, input, output interface{}) error {
+		*output.(*string) = "user"
+
+		return nil
+	})
+
+	orders := usecase.NewIOI(new(int), new([]int), func(ctx context.Context, input, output interface{}) error {
+		*output.(*[]int) = []int{*input.(*int), 2}
+
+		return nil
+	})
+
+	var recommendations int64
+
+	u := usecase.NewParallel(new(int), new(dashboard), usecase.Parallel{
+		Branches: []usecase.ParallelBranch{
+			{
+				Interactor: profile,
+				Output: func(output interface{}) interface{} {
+					return &output.(*dashboard).Profile
+				},
+			},
+			{
+				Interactor: orders,
+				Output: func(output interface{}) interface{} {
+					return &output.(*dashboard).Orders
+				},
+			},
+			{
+				Interactor: usecase.NewIOI(nil, new(int), func(ctx context.Context, input, output interface{}) error {
+					atomic.AddInt64(&recommendations, 1)
+					*output.(*int) = 1
+
+					return nil
+				}),
+			},
+		},
+	}, func(i *usecase.IOInteractor) {
+		i.SetTags("dashboard")
+	})
+
+	assert.Equal(t, "swaggest/usecase_test.TestNewParallel", u.Name())
+	assert.Equal(t, []string{"dashboard"}, u.Tags())
+
+	in := 1
+	out := dashboard{}
+
+	require.NoError(t, u.Interact(context.Background(), &in, &out))
+	assert.Equal(t, dashboard{Profile: "user", Orders: []int{1, 2}}, out)
+	assert.Equal(t, int64(1), atomic.LoadInt64(&recommendations))
+}
+
+func TestParallel_Interact_failFast(t *testing.T) {
+	failing := usecase.NewIOI(nil, nil, func(ctx context.Context, input, output interface{}) error {
+		return status.Wrap(errors.New("no orders"), status.NotFound)
+	})
+	failing.SetName("orders")
+
+	slow := usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+			return nil
+		}
+	})
+
+	p := usecase.Parallel{
+		Branches: []usecase.ParallelBranch{
+			{Interactor: slow},
+			{Interactor: failing},
+			{Interactor: slow},
+		},
+	}
+
+	err := p.Interact(context.Background(), nil, nil)
+	assert.EqualError(t, err, "orders: not found: no orders")
+	assert.True(t, errors.Is(err, status.NotFound))
+
+	var pe usecase.ParallelError
+
+	require.True(t, errors.As(err, &pe))
+	assert.Len(t, pe.Errors, 1)
+	assert.Equal(t, status.NotFound, pe.Status())
+	assert.EqualError(t, pe.Unwrap(), "orders: not found: no orders")
+}
+
+func TestParallel_Interact_collectAll(t *testing.T) {
+	var running, maxRunning int64
+
+	branch := func(err error) usecase.Interactor {
+		return usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			r := atomic.AddInt64(&running, 1)
+			defer atomic.AddInt64(&running, -1)
+
+			for {
+				m := atomic.LoadInt64(&maxRunning)
+				if r <= m || atomic.CompareAndSwapInt64(&maxRunning, m, r) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			return err
+		})
+	}
+
+	p := usecase.Parallel{
+		Limit:      2,
+		CollectAll: true,
+		Branches: []usecase.ParallelBranch{
+			{Interactor: branch(nil)},
+			{Interactor: branch(status.NotFound)},
+			{Interactor: branch(nil)},
+			{Interactor: branch(status.Internal)},
+			{Interactor: branch(nil)},
+		},
+	}
+
+	err := p.Interact(context.Background(), nil, nil)
+
+	var pe usecase.ParallelError
+
+	require.True(t, errors.As(err, &pe))
+	assert.Len(t, pe.Errors, 2)
+	assert.Equal(t, status.Unknown, pe.Status())
+	assert.True(t, errors.Is(err, status.NotFound))
+	assert.True(t, errors.Is(err, status.Internal))
+	assert.False(t, errors.Is(err, status.Canceled))
+	assert.LessOrEqual(t, atomic.LoadInt64(&maxRunning), int64(2))
+
+	p.Branches = p.Branches[2:4]
+	p.Branches[1].Input = func(ctx context.Context, input interface{}) (interface{}, error) {
+		return nil, status.InvalidArgument
+	}
+
+	err = p.Interact(context.Background(), nil, nil)
+	assert.EqualError(t, err, "step 2: invalid argument")
+	assert.Equal(t, status.InvalidArgument, err.(usecase.ParallelError).Status())
+
+	assert.Equal(t, status.Code(0), usecase.ParallelError{}.Status())
+	assert.Nil(t, usecase.ParallelError{}.Unwrap())
+}
+
+func TestParallel_Interact_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := usecase.Parallel{
+		Limit: 1,
+		Branches: []usecase.ParallelBranch{
+			{Interactor: usecase.Interact(func(ctx context.Context, input, output interface{}) error { return nil })},
+			{Interactor: usecase.Interact(func(ctx context.Context, input, output interface{}) error { return nil })},
+		},
+	}
+
+	err := p.Interact(ctx, nil, nil)
+	assert.True(t, errors.Is(err, status.Canceled))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
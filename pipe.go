@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// PipeStep is a stage of sequential pipeline.
+type PipeStep struct {
+	Interactor
+
+	// Map converts output of previous step into input of this step, optional.
+	// It can not be used in the first step, because pipeline input port is taken from that step.
+	//
+	// Without Map, previous output is passed as input if it is assignable to input port of this step,
+	// pointer output is dereferenced for value input port.
+	Map func(ctx context.Context, prevOutput interface{}) (interface{}, error)
+}
+
+// NewPipe creates use case interactor that invokes steps sequentially,
+// output of a step becomes input of the next step.
+//
+// Input port is taken from the first step, output port from the last step.
+// Errors are annotated with the name of failed step.
+// It pre-fills name and title with caller function.
+func NewPipe(steps []PipeStep, options ...func(i *IOInteractor)) IOInteractor {
+	if len(steps) == 0 {
+		panic("steps must not be empty")
+	}
+
+	if steps[0].Map != nil {
+		panic("first step must not have Map, pipeline input is passed to it as is")
+	}
+
+	u := IOInteractor{}
+	u.Input = inputPort(steps[0].Interactor)
+	u.Output = outputPort(steps[len(steps)-1].Interactor)
+	u.Interactor = Interact(func(ctx context.Context, input, output interface{}) error {
+		last := len(steps) - 1
+		in := input
+
+		for idx, s := range steps {
+			var err error
+
+			if idx > 0 {
+				if s.Map != nil {
+					in, err = s.Map(ctx, in)
+				} else {
+					in, err = pipeInput(in, inputPort(s.Interactor))
+				}
+			}
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", stepName(s.Interactor, idx), err)
+			}
+
+			out := output
+			if idx != last {
+				out = NewOutput(s.Interactor)
+			}
+
+			if err := s.Interact(ctx, in, out); err != nil {
+				return fmt.Errorf("%s: %w", stepName(s.Interactor, idx), err)
+			}
+
+			in = out
+		}
+
+		return nil
+	})
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u)
+	}
+
+	return u
+}
+
+func inputPort(u Interactor) interface{} {
+	// Direct assertion avoids reflection of As for unwrapped interactors.
+	if withInput, ok := u.(HasInputPort); ok {
+		return withInput.InputPort()
+	}
+
+	var withInput HasInputPort
+
+	if As(u, &withInput) {
+		return withInput.InputPort()
+	}
+
+	return nil
+}
+
+func outputPort(u Interactor) interface{} {
+	if withOutput, ok := u.(HasOutputPort); ok {
+		return withOutput.OutputPort()
+	}
+
+	var withOutput HasOutputPort
+
+	if As(u, &withOutput) {
+		return withOutput.OutputPort()
+	}
+
+	return nil
+}
+
+// stepName returns name of use case or its position.
+func stepName(u Interactor, idx int) string {
+	var withName HasName
+
+	if As(u, &withName) && withName.Name() != "" {
+		return withName.Name()
+	}
+
+	return fmt.Sprintf("step %d", idx+1)
+}
+
+// pipeInput converts output of previous step to input port type.
+func pipeInput(prevOutput, port interface{}) (interface{}, error) {
+	if port == nil || prevOutput == nil {
+		return prevOutput, nil
+	}
+
+	pt := reflect.TypeOf(port)
+	ov := reflect.ValueOf(prevOutput)
+
+	if ov.Type().AssignableTo(pt) {
+		return prevOutput, nil
+	}
+
+	if ov.Kind() == reflect.Ptr && !ov.IsNil() && ov.Elem().Type().AssignableTo(pt) {
+		return ov.Elem().Interface(), nil
+	}
+
+	return nil, fmt.Errorf("%w of input: %T, expected: %T", ErrInvalidType, prevOutput, port)
+}
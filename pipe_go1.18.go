@@ -0,0 +1,65 @@
+//go:build go1.18
+// +build go1.18
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewPipeOf creates generic use case interactor that passes output of the first interactor
+// as input of the second one.
+//
+// Optional mapper converts output of the first interactor into input of the second one.
+// If mapper is nil, output is passed as is, or by pointer, when types are compatible.
+// Errors are annotated with the name of failed interactor.
+// It pre-fills name and title with caller function.
+func NewPipeOf[i, m, n, o any](
+	first IOInteractorOf[i, m],
+	second IOInteractorOf[n, o],
+	mapper func(ctx context.Context, output m) (n, error),
+	options ...func(i *IOInteractor),
+) IOInteractorOf[i, o] {
+	if mapper == nil {
+		mapper = func(ctx context.Context, output m) (n, error) {
+			if v, ok := any(output).(n); ok {
+				return v, nil
+			}
+
+			if v, ok := any(&output).(n); ok {
+				return v, nil
+			}
+
+			return *new(n), fmt.Errorf("%w of input: %T, expected: %T", ErrInvalidType, output, *new(n))
+		}
+	}
+
+	u := newInteractorOf(func(ctx context.Context, input i, output *o) error {
+		var mid m
+
+		if err := first.Invoke(ctx, input, &mid); err != nil {
+			return fmt.Errorf("%s: %w", stepName(first, 0), err)
+		}
+
+		in, err := mapper(ctx, mid)
+		if err != nil {
+			return fmt.Errorf("%s: %w", stepName(second, 1), err)
+		}
+
+		if err := second.Invoke(ctx, in, output); err != nil {
+			return fmt.Errorf("%s: %w", stepName(second, 1), err)
+		}
+
+		return nil
+	})
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u.IOInteractor)
+	}
+
+	return u
+}
@@ -0,0 +1,88 @@
+//go:build go1.18
+// +build go1.18
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+func TestNewPipeOf(t *testing.T) {
+	parse := usecase.NewInteractor(func(ctx context.Context, input string, output *int) error {
+		id, err := strconv.Atoi(input)
+		if err != nil {
+			return status.Wrap(err, status.InvalidArgument)
+		}
+
+		*output = id
+
+		return nil
+	})
+	parse.SetName("parse")
+
+	load := usecase.NewInteractor(func(ctx context.Context, input int, output *pipeUser) error {
+		*output = pipeUser{ID: input, Name: "Jane"}
+
+		return nil
+	})
+	load.SetName("load")
+
+	greet := usecase.NewInteractor(func(ctx context.Context, input *pipeUser, output *pipeGreeting) error {
+		if input.ID == 0 {
+			return status.NotFound
+		}
+
+		output.Text = "Hello, " + input.Name + "!"
+
+		return nil
+	})
+
+	u := usecase.NewPipeOf(usecase.NewPipeOf(parse, load, nil), greet, nil, func(i *usecase.IOInteractor) {
+		i.SetTags("pipe")
+	})
+
+	assert.Equal(t, "swaggest/usecase_test.TestNewPipeOf", u.Name())
+	assert.Equal(t, []string{"pipe"}, u.Tags())
+	assert.Equal(t, "", u.InputPort())
+	assert.Equal(t, new(pipeGreeting), u.OutputPort())
+
+	ctx := context.Background()
+	out := pipeGreeting{}
+
+	require.NoError(t, u.Invoke(ctx, "123", &out))
+	assert.Equal(t, "Hello, Jane!", out.Text)
+
+	out = pipeGreeting{}
+	require.NoError(t, u.Interact(ctx, "123", &out))
+	assert.Equal(t, "Hello, Jane!", out.Text)
+
+	err := u.Invoke(ctx, "abc", &out)
+	assert.True(t, errors.Is(err, status.InvalidArgument))
+	assert.EqualError(t, err, `swaggest/usecase_test.TestNewPipeOf: parse: invalid argument: strconv.Atoi: parsing "abc": invalid syntax`)
+
+	err = u.Invoke(ctx, "0", &out)
+	assert.True(t, errors.Is(err, status.NotFound))
+	assert.EqualError(t, err, "swaggest/usecase_test.TestNewPipeOf: not found")
+
+	mapped := usecase.NewPipeOf(parse, parse, func(ctx context.Context, output int) (string, error) {
+		return strconv.Itoa(output * 2), nil
+	})
+
+	var n int
+
+	require.NoError(t, mapped.Invoke(ctx, "21", &n))
+	assert.Equal(t, 42, n)
+
+	mismatched := usecase.NewPipeOf(parse, parse, nil)
+	err = mismatched.Invoke(ctx, "21", &n)
+	assert.True(t, errors.Is(err, usecase.ErrInvalidType))
+	assert.EqualError(t, err, "parse: invalid type of input: int, expected: string")
+}
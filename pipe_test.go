@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type pipeUser struct {
+	ID   int
+	Name string
+}
+
+type pipeGreeting struct {
+	Text string
+}
+
+func TestNewPipe(t *testing.T) {
+	parse := usecase.NewIOI(new(string), new(int), func(ctx context.Context, input, output interface{}) error {
+		id, err := strconv.Atoi(*input.(*string))
+		if err != nil {
+			return status.Wrap(err, status.InvalidArgument)
+		}
+
+		*output.(*int) = id
+
+		return nil
+	})
+	parse.SetName("parse")
+
+	load := usecase.NewIOI(0, new(pipeUser), func(ctx context.Context, input, output interface{}) error {
+		id := input.(int)
+		if id == 0 {
+			return status.NotFound
+		}
+
+		*output.(*pipeUser) = pipeUser{ID: id, Name: "Jane"}
+
+		return nil
+	})
+
+	greet := usecase.NewIOI(new(pipeUser), new(pipeGreeting), func(ctx context.Context, input, output interface{}) error {
+		output.(*pipeGreeting).Text = "Hello, " + input.(*pipeUser).Name + "!"
+
+		return nil
+	})
+
+	u := usecase.NewPipe([]usecase.PipeStep{
+		{Interactor: parse},
+		{Interactor: load},
+		{Interactor: greet},
+	}, func(i *usecase.IOInteractor) {
+		i.SetTags("pipe")
+	})
+
+	assert.Equal(t, "swaggest/usecase_test.TestNewPipe", u.Name())
+	assert.Equal(t, "Test New Pipe", u.Title())
+	assert.Equal(t, []string{"pipe"}, u.Tags())
+	assert.Equal(t, new(string), u.InputPort())
+	assert.Equal(t, new(pipeGreeting), u.OutputPort())
+
+	ctx := context.Background()
+	in := "123"
+	out := pipeGreeting{}
+
+	require.NoError(t, u.Interact(ctx, &in, &out))
+	assert.Equal(t, "Hello, Jane!", out.Text)
+
+	in = "abc"
+	err := u.Interact(ctx, &in, &out)
+	assert.True(t, errors.Is(err, status.InvalidArgument))
+	assert.EqualError(t, err, `parse: invalid argument: strconv.Atoi: parsing "abc": invalid syntax`)
+
+	in = "0"
+	err = u.Interact(ctx, &in, &out)
+	assert.True(t, errors.Is(err, status.NotFound))
+	assert.EqualError(t, err, "swaggest/usecase_test.TestNewPipe: not found")
+}
+
+func TestNewPipe_map(t *testing.T) {
+	double := usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		*output.(*int) = input.(int) * 2
+
+		return nil
+	})
+
+	u := usecase.NewPipe([]usecase.PipeStep{
+		{Interactor: usecase.NewIOI(0, new(int), double)},
+		{
+			Interactor: usecase.NewIOI(0, new(int), double),
+			Map: func(ctx context.Context, prevOutput interface{}) (interface{}, error) {
+				return *prevOutput.(*int) + 1, nil
+			},
+		},
+		{
+			Interactor: double,
+			Map: func(ctx context.Context, prevOutput interface{}) (interface{}, error) {
+				return nil, errors.New("failed")
+			},
+		},
+	})
+
+	out := 0
+	assert.EqualError(t, u.Interact(context.Background(), 5, &out), "step 3: failed")
+
+	u = usecase.NewPipe([]usecase.PipeStep{
+		{Interactor: usecase.NewIOI(0, new(int), double)},
+		{Interactor: usecase.NewIOI("", new(int), double)},
+	})
+
+	err := u.Interact(context.Background(), 5, &out)
+	assert.True(t, errors.Is(err, usecase.ErrInvalidType))
+	assert.EqualError(t, err, "swaggest/usecase_test.TestNewPipe_map: invalid type of input: *int, expected: string")
+
+	assert.Panics(t, func() {
+		usecase.NewPipe(nil)
+	})
+
+	assert.Panics(t, func() {
+		usecase.NewPipe([]usecase.PipeStep{{
+			Interactor: usecase.NewIOI(0, new(int), double),
+			Map: func(ctx context.Context, prevOutput interface{}) (interface{}, error) {
+				return prevOutput, nil
+			},
+		}})
+	})
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Resetter is implemented by ports that clear their state for reuse in PortPool.
+type Resetter interface {
+	Reset()
+}
+
+// PortPool reuses input and output port values of use case interactor.
+//
+// Values are reset when put back, with Reset method if port implements Resetter,
+// or by zeroing with reflection otherwise.
+// Value must not be used by caller after it was put back.
+type PortPool struct {
+	input   *PortInfo
+	output  *PortInfo
+	inputs  sync.Pool
+	outputs sync.Pool
+}
+
+// NewPortPool creates pool for ports of use case interactor, ports are found with As.
+func NewPortPool(u Interactor) *PortPool {
+	p := &PortPool{}
+
+	if port := inputPort(u); port != nil {
+		p.input = PortInfoOf(port)
+
+		// Value input is copied when passed, so it is not pooled.
+		if p.input.IsPtr {
+			p.inputs.New = newPortValue(p.input.Type)
+		}
+	}
+
+	if port := outputPort(u); port != nil {
+		p.output = PortInfoOf(port)
+		p.outputs.New = newPortValue(p.output.Type)
+	}
+
+	return p
+}
+
+// GetInput returns input value shaped like NewInput.
+func (p *PortPool) GetInput() interface{} {
+	if p.inputs.New != nil {
+		return p.inputs.Get()
+	}
+
+	if p.input != nil {
+		return reflect.Zero(p.input.Type).Interface()
+	}
+
+	return nil
+}
+
+// PutInput resets input and puts it back to pool.
+func (p *PortPool) PutInput(input interface{}) {
+	if p.inputs.New != nil {
+		put(&p.inputs, p.input.Type, input)
+	}
+}
+
+// GetOutput returns pointer to output value.
+func (p *PortPool) GetOutput() interface{} {
+	if p.outputs.New != nil {
+		return p.outputs.Get()
+	}
+
+	return nil
+}
+
+// PutOutput resets output and puts it back to pool.
+func (p *PortPool) PutOutput(output interface{}) {
+	if p.outputs.New != nil {
+		put(&p.outputs, p.output.Type, output)
+	}
+}
+
+func newPortValue(t reflect.Type) func() interface{} {
+	return func() interface{} {
+		return reflect.New(t).Interface()
+	}
+}
+
+// put resets value and adds it to pool, values of other types are ignored.
+func put(pool *sync.Pool, elem reflect.Type, value interface{}) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Type().Elem() != elem {
+		return
+	}
+
+	if r, ok := value.(Resetter); ok {
+		r.Reset()
+	} else {
+		v.Elem().Set(reflect.Zero(elem))
+	}
+
+	pool.Put(value)
+}
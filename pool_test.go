@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+)
+
+type pooledOutput struct {
+	Items  []string `json:"items"`
+	Total  int      `json:"total"`
+	resets int
+}
+
+func (o *pooledOutput) Reset() {
+	o.Items = o.Items[:0]
+	o.Total = 0
+	o.resets++
+}
+
+func TestPortPool(t *testing.T) {
+	u := usecase.NewIOI(new(privateBase), new(pooledOutput), func(ctx context.Context, input, output interface{}) error {
+		out := output.(*pooledOutput)
+		out.Items = append(out.Items, "a", "b")
+		out.Total = input.(*privateBase).ID
+
+		return nil
+	})
+
+	p := usecase.NewPortPool(u)
+
+	in, ok := p.GetInput().(*privateBase)
+	assert.True(t, ok)
+
+	in.ID = 2
+
+	out, ok := p.GetOutput().(*pooledOutput)
+	assert.True(t, ok)
+
+	assert.NoError(t, u.Interact(context.Background(), in, out))
+	assert.Equal(t, 2, out.Total)
+
+	p.PutInput(in)
+	assert.Equal(t, privateBase{}, *in)
+
+	p.PutOutput(out)
+	assert.Equal(t, 0, out.Total)
+	assert.Empty(t, out.Items)
+	assert.Equal(t, 1, out.resets)
+
+	// Values of other types are ignored.
+	p.PutInput(privateBase{})
+	p.PutOutput(new(int))
+	p.PutOutput(nil)
+}
+
+func TestPortPool_valueInput(t *testing.T) {
+	p := usecase.NewPortPool(usecase.NewIOI(privateBase{ID: 1}, nil, nil))
+
+	assert.Equal(t, privateBase{}, p.GetInput())
+	assert.Nil(t, p.GetOutput())
+
+	p.PutInput(privateBase{ID: 2})
+	p.PutOutput(new(privateBase))
+
+	p = usecase.NewPortPool(usecase.Interact(nil))
+	assert.Nil(t, p.GetInput())
+	assert.Nil(t, p.GetOutput())
+}
+
+func BenchmarkPortPool(b *testing.B) {
+	u := usecase.NewIOI(new(privateBase), new(pooledOutput), func(ctx context.Context, input, output interface{}) error {
+		output.(*pooledOutput).Total = input.(*privateBase).ID
+
+		return nil
+	})
+
+	ctx := context.Background()
+
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			in := usecase.NewInput(u)
+			out := usecase.NewOutput(u)
+
+			_ = u.Interact(ctx, in, out)
+		}
+	})
+
+	b.Run("pool", func(b *testing.B) {
+		p := usecase.NewPortPool(u)
+
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			in := p.GetInput()
+			out := p.GetOutput()
+
+			_ = u.Interact(ctx, in, out)
+
+			p.PutInput(in)
+			p.PutOutput(out)
+		}
+	})
+}
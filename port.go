@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidPort is returned when input or output port can not be used by transport.
+const ErrInvalidPort = sentinelError("invalid port")
+
+// ValidatePorts checks that input and output port samples can be instantiated and populated by transport.
+//
+// Nil ports are valid, output must be a pointer. Ports must not be pointers to pointers or interfaces,
+// and structures must have exported fields unless they implement JSON or text unmarshaling.
+func ValidatePorts(input, output interface{}) error {
+	if err := validatePort("input", input); err != nil {
+		return err
+	}
+
+	if output != nil && reflect.TypeOf(output).Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: output %T is not a pointer", ErrInvalidPort, output)
+	}
+
+	return validatePort("output", output)
+}
+
+// Validate checks ports of use case interactor, they are found with As.
+func Validate(u Interactor) error {
+	if err := validateInteractorPorts(u); err != nil {
+		var withName HasName
+
+		if As(u, &withName) && withName.Name() != "" {
+			return fmt.Errorf("%s: %w", withName.Name(), err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func validateInteractorPorts(u Interactor) error {
+	// Interface input of generic interactor has nil sample, so its type is checked separately.
+	var withInputType interface {
+		inputType() reflect.Type
+	}
+
+	if As(u, &withInputType) && withInputType.inputType().Kind() == reflect.Interface {
+		return fmt.Errorf("%w: input %s is an interface", ErrInvalidPort, withInputType.inputType())
+	}
+
+	return ValidatePorts(inputPort(u), outputPort(u))
+}
+
+// Validate checks input and output ports.
+func (i IOInteractor) Validate() error {
+	return Validate(i)
+}
+
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	jsonMarshaler   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func validatePort(name string, port interface{}) error {
+	if port == nil {
+		return nil
+	}
+
+	p := PortInfoOf(port)
+	t := p.Type
+
+	if p.IsPtr {
+		switch t.Kind() { //nolint:exhaustive // Other kinds are valid.
+		case reflect.Ptr:
+			return fmt.Errorf("%w: %s %T is a pointer to pointer", ErrInvalidPort, name, port)
+		case reflect.Interface:
+			return fmt.Errorf("%w: %s %T is a pointer to interface", ErrInvalidPort, name, port)
+		}
+	}
+
+	if t.Kind() == reflect.Struct && t.NumField() > 0 && len(p.Fields) == 0 && !isCodec(t) {
+		return fmt.Errorf("%w: %s %T has no exported fields", ErrInvalidPort, name, port)
+	}
+
+	return nil
+}
+
+// isCodec checks if type has custom JSON or text encoding.
+func isCodec(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+
+	return pt.Implements(jsonUnmarshaler) || pt.Implements(jsonMarshaler) ||
+		pt.Implements(textUnmarshaler) || pt.Implements(textMarshaler)
+}
+
+// NewInput creates fresh value of input port of use case interactor, input port is found with As.
+//
+// Result is shaped like input port sample: pointer to a new value for pointer port (classic API),
+// or zero value for value port (e.g. IOInteractorOf). It returns nil if there is no input port.
+func NewInput(u Interactor) interface{} {
+	port := inputPort(u)
+	if port == nil {
+		return nil
+	}
+
+	p := PortInfoOf(port)
+	if !p.IsPtr {
+		return reflect.Zero(p.Type).Interface()
+	}
+
+	return reflect.New(p.Type).Interface()
+}
+
+// NewOutput creates pointer to fresh value of output port of use case interactor,
+// output port is found with As.
+//
+// It returns nil if there is no output port.
+func NewOutput(u Interactor) interface{} {
+	port := outputPort(u)
+	if port == nil {
+		return nil
+	}
+
+	return reflect.New(PortInfoOf(port).Type).Interface()
+}
@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+)
+
+type privateOnly struct {
+	id int
+}
+
+type embedsExported struct {
+	privateBase
+}
+
+type privateBase struct {
+	ID int `json:"id"`
+}
+
+func TestValidatePorts(t *testing.T) {
+	type output struct {
+		Value string `json:"value"`
+	}
+
+	for _, tc := range []struct {
+		input, output interface{}
+		err           string
+	}{
+		{},
+		{input: output{}, output: new(output)},
+		{input: new(output), output: new(output)},
+		{input: new(int), output: new([]string)},
+		{input: struct{}{}, output: new(struct{})},
+		{input: new(time.Time), output: new(time.Time)},
+		{input: new(embedsExported), output: new(embedsExported)},
+		{output: new(struct{ usecase.OutputWithEventStream })},
+		{output: new(struct{ usecase.OutputWithNoContent })},
+		{output: output{}, err: "invalid port: output usecase_test.output is not a pointer"},
+		{input: new(*output), err: "invalid port: input **usecase_test.output is a pointer to pointer"},
+		{output: new(*output), err: "invalid port: output **usecase_test.output is a pointer to pointer"},
+		{input: new(io.Reader), err: "invalid port: input *io.Reader is a pointer to interface"},
+		{output: new(interface{}), err: "invalid port: output *interface {} is a pointer to interface"},
+		{input: privateOnly{}, err: "invalid port: input usecase_test.privateOnly has no exported fields"},
+		{output: new(privateOnly), err: "invalid port: output *usecase_test.privateOnly has no exported fields"},
+	} {
+		err := usecase.ValidatePorts(tc.input, tc.output)
+
+		if tc.err == "" {
+			assert.NoError(t, err)
+
+			continue
+		}
+
+		assert.ErrorIs(t, err, usecase.ErrInvalidPort)
+		assert.EqualError(t, err, tc.err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	u := usecase.NewIOI(nil, privateBase{}, nil)
+	u.SetName("getItem")
+
+	assert.EqualError(t, u.Validate(), "getItem: invalid port: output usecase_test.privateBase is not a pointer")
+
+	w := usecase.Wrap(u, usecase.MiddlewareFunc(func(next usecase.Interactor) usecase.Interactor {
+		return usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			return next.Interact(ctx, input, output)
+		})
+	}))
+
+	assert.ErrorIs(t, usecase.Validate(w), usecase.ErrInvalidPort)
+
+	require.NoError(t, usecase.Validate(usecase.NewIOI(new(privateBase), new(privateBase), nil)))
+	require.NoError(t, usecase.Validate(usecase.Interact(nil)))
+}
+
+func TestNewInput(t *testing.T) {
+	u := usecase.NewIOI(new(privateBase), new(privateBase), nil)
+
+	in1 := usecase.NewInput(u)
+	in2 := usecase.NewInput(u)
+
+	assert.Equal(t, &privateBase{}, in1)
+	assert.NotSame(t, in1, in2)
+
+	assert.Equal(t, privateBase{}, usecase.NewInput(usecase.NewIOI(privateBase{ID: 1}, nil, nil)))
+	assert.Nil(t, usecase.NewInput(usecase.NewIOI(nil, nil, nil)))
+	assert.Nil(t, usecase.NewInput(usecase.Interact(nil)))
+
+	w := usecase.Wrap(u, usecase.ErrorCatcher(func(ctx context.Context, input interface{}, err error) {}))
+	assert.Equal(t, &privateBase{}, usecase.NewInput(w))
+}
+
+func TestNewOutput(t *testing.T) {
+	u := usecase.NewIOI(nil, &privateBase{ID: 1}, nil)
+
+	out1 := usecase.NewOutput(u)
+	out2 := usecase.NewOutput(u)
+
+	assert.Equal(t, &privateBase{}, out1)
+	assert.NotSame(t, out1, out2)
+
+	assert.Equal(t, &privateBase{}, usecase.NewOutput(usecase.NewIOI(nil, privateBase{}, nil)))
+	assert.Nil(t, usecase.NewOutput(usecase.NewIOI(nil, nil, nil)))
+
+	w := usecase.Wrap(u, usecase.ErrorCatcher(func(ctx context.Context, input interface{}, err error) {}))
+	assert.Equal(t, &privateBase{}, usecase.NewOutput(w))
+}
+
+func BenchmarkNewOutput(b *testing.B) {
+	u := usecase.NewIOI(new(privateBase), new(privateBase), nil)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = usecase.NewOutput(u)
+	}
+}
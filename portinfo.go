@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// PortInfo is a cached reflection metadata of input or output port.
+type PortInfo struct {
+	// Type is a type of port value, it is dereferenced for pointer port.
+	Type reflect.Type
+
+	// IsPtr is true if port sample is a pointer.
+	IsPtr bool
+
+	// Fields are exported fields of structure, including embedded and promoted fields.
+	// Fields that are shadowed or ambiguous are omitted.
+	Fields []PortField
+
+	// Embedded are types of embedded structures, dereferenced for pointers.
+	Embedded []reflect.Type
+
+	// WithWriter is true if pointer to port value implements OutputWithWriter.
+	WithWriter bool
+
+	// WithNoContent is true if pointer to port value can discard output, e.g. with embedded OutputWithNoContent.
+	WithNoContent bool
+}
+
+// PortField describes field of structure port.
+type PortField struct {
+	Name string
+	Type reflect.Type
+
+	// Anonymous is true for embedded field, its fields are also listed as promoted.
+	Anonymous bool
+
+	// Index is a sequence of field indexes for reflect.Value.FieldByIndex.
+	//
+	// Index may go through embedded pointers, reflect.Value.FieldByIndex panics
+	// if they are nil.
+	Index []int
+
+	// Tags are parsed field tags by key.
+	Tags map[string]string
+}
+
+// Field returns field by name.
+func (p *PortInfo) Field(name string) (PortField, bool) {
+	for _, f := range p.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+
+	return PortField{}, false
+}
+
+var (
+	// portInfos caches PortInfo by type of port sample.
+	portInfos sync.Map
+
+	// portTypes caches PortInfo by dereferenced type, so that T and *T share one parse.
+	portTypes sync.Map
+)
+
+var (
+	outputWithWriter    = reflect.TypeOf((*OutputWithWriter)(nil)).Elem()
+	outputWithNoContent = reflect.TypeOf((*interface {
+		SetNoContent(enabled bool)
+		NoContent() bool
+	})(nil)).Elem()
+)
+
+// PortInfoOf returns reflection metadata of port sample, e.g. value of InputPort() or OutputPort().
+//
+// Metadata is collected once per type and shared, it must not be modified.
+// It returns nil for nil port.
+func PortInfoOf(port interface{}) *PortInfo {
+	if port == nil {
+		return nil
+	}
+
+	t := reflect.TypeOf(port)
+
+	if p, ok := portInfos.Load(t); ok {
+		return p.(*PortInfo) //nolint:forcetypeassert // Cache has only *PortInfo values.
+	}
+
+	var p *PortInfo
+
+	if t.Kind() == reflect.Ptr {
+		pp := *portTypeInfo(t.Elem())
+		pp.IsPtr = true
+		p = &pp
+	} else {
+		p = portTypeInfo(t)
+	}
+
+	v, _ := portInfos.LoadOrStore(t, p)
+
+	return v.(*PortInfo) //nolint:forcetypeassert // Cache has only *PortInfo values.
+}
+
+// portTypeInfo returns metadata of port value type.
+func portTypeInfo(t reflect.Type) *PortInfo {
+	if p, ok := portTypes.Load(t); ok {
+		return p.(*PortInfo) //nolint:forcetypeassert // Cache has only *PortInfo values.
+	}
+
+	p := &PortInfo{Type: t}
+
+	pt := reflect.PtrTo(t)
+	p.WithWriter = pt.Implements(outputWithWriter)
+	p.WithNoContent = pt.Implements(outputWithNoContent)
+
+	if t.Kind() == reflect.Struct {
+		for _, f := range visibleFields(t) {
+			if f.Anonymous {
+				if ft := embeddedStruct(f.Type); ft != nil {
+					p.Embedded = append(p.Embedded, ft)
+				}
+			}
+
+			if f.PkgPath != "" {
+				continue
+			}
+
+			p.Fields = append(p.Fields, PortField{
+				Name:      f.Name,
+				Type:      f.Type,
+				Anonymous: f.Anonymous,
+				Index:     f.Index,
+				Tags:      parseTags(f.Tag),
+			})
+		}
+	}
+
+	v, _ := portTypes.LoadOrStore(t, p)
+
+	return v.(*PortInfo) //nolint:forcetypeassert // Cache has only *PortInfo values.
+}
+
+// visibleFields returns fields of structure including promoted ones, with full index paths.
+//
+// Fields are walked breadth-first by embedding depth, a name that is already seen at lower depth
+// is shadowed and a name that occurs more than once at the same depth is ambiguous, both are omitted
+// like Go selectors do. Result is ordered by index, so promoted fields follow their embedded field.
+func visibleFields(t reflect.Type) []reflect.StructField {
+	type level struct {
+		t reflect.Type
+
+		// index is a path to embedded field of type t.
+		index []int
+
+		// parents are types on the path, they are not walked again to break recursive embedding.
+		parents []reflect.Type
+	}
+
+	var (
+		fields  []reflect.StructField
+		seen    = map[string]bool{}
+		current = []level{{t: t}}
+	)
+
+	for len(current) > 0 {
+		var (
+			next   []level
+			found  []reflect.StructField
+			counts = map[string]int{}
+		)
+
+		for _, l := range current {
+			parents := append(append([]reflect.Type(nil), l.parents...), l.t)
+
+			for i := 0; i < l.t.NumField(); i++ {
+				f := l.t.Field(i)
+				f.Index = append(append([]int(nil), l.index...), i)
+
+				if f.Anonymous {
+					if ft := embeddedStruct(f.Type); ft != nil && !hasType(parents, ft) {
+						next = append(next, level{t: ft, index: f.Index, parents: parents})
+					}
+				}
+
+				if seen[f.Name] {
+					continue
+				}
+
+				counts[f.Name]++
+				found = append(found, f)
+			}
+		}
+
+		for _, f := range found {
+			if counts[f.Name] == 1 {
+				fields = append(fields, f)
+			}
+		}
+
+		for name := range counts {
+			seen[name] = true
+		}
+
+		current = next
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].Index, fields[j].Index
+
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+
+		return len(a) < len(b)
+	})
+
+	return fields
+}
+
+func hasType(types []reflect.Type, t reflect.Type) bool {
+	for _, tt := range types {
+		if tt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// embeddedStruct returns structure type of embedded field, dereferenced for pointer, or nil.
+func embeddedStruct(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
+
+// parseTags parses conventional struct tag into a map, malformed tail is ignored.
+func parseTags(tag reflect.StructTag) map[string]string {
+	tags := make(map[string]string)
+
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+
+		name := string(tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+
+			i++
+		}
+
+		if i >= len(tag) {
+			break
+		}
+
+		value, err := strconv.Unquote(string(tag[:i+1]))
+		if err != nil {
+			break
+		}
+
+		tag = tag[i+1:]
+		tags[name] = value
+	}
+
+	return tags
+}
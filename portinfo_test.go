@@ -0,0 +1,147 @@
+package usecase_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+)
+
+type infoBase struct {
+	ID     int    `json:"id" minimum:"1"`
+	Shadow string `json:"base"`
+}
+
+type InfoMeta struct {
+	Version int `json:"version"`
+}
+
+type infoPort struct {
+	infoBase
+	*InfoMeta
+	usecase.OutputWithNoContent
+
+	Name   string `json:"name" description:"Name with \"quotes\"." required:"true"`
+	Shadow string `json:"shadow"`
+	hidden int
+}
+
+func TestPortInfoOf(t *testing.T) {
+	p := usecase.PortInfoOf(new(infoPort))
+	require.NotNil(t, p)
+
+	assert.Same(t, p, usecase.PortInfoOf(&infoPort{Name: "other"}))
+	assert.Equal(t, reflect.TypeOf(infoPort{}), p.Type)
+	assert.True(t, p.IsPtr)
+	assert.False(t, p.WithWriter)
+	assert.True(t, p.WithNoContent)
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeOf(infoBase{}),
+		reflect.TypeOf(InfoMeta{}),
+		reflect.TypeOf(usecase.OutputWithNoContent{}),
+	}, p.Embedded)
+
+	names := make([]string, 0, len(p.Fields))
+	for _, f := range p.Fields {
+		names = append(names, f.Name)
+	}
+
+	assert.Equal(t, []string{"ID", "InfoMeta", "Version", "OutputWithNoContent", "Name", "Shadow"}, names)
+
+	f, found := p.Field("Name")
+	require.True(t, found)
+	assert.Equal(t, map[string]string{
+		"json":        "name",
+		"description": `Name with "quotes".`,
+		"required":    "true",
+	}, f.Tags)
+
+	id, found := p.Field("ID")
+	require.True(t, found)
+	assert.Equal(t, []int{0, 0}, id.Index)
+	assert.Equal(t, "1", id.Tags["minimum"])
+
+	v := reflect.ValueOf(infoPort{infoBase: infoBase{ID: 3}})
+	assert.Equal(t, 3, v.FieldByIndex(id.Index).Interface())
+
+	f, found = p.Field("Shadow")
+	require.True(t, found)
+	assert.Equal(t, "shadow", f.Tags["json"])
+
+	_, found = p.Field("hidden")
+	assert.False(t, found)
+
+	f, found = p.Field("InfoMeta")
+	require.True(t, found)
+	assert.True(t, f.Anonymous)
+
+	// Index goes through nil embedded pointer.
+	f, found = p.Field("Version")
+	require.True(t, found)
+	assert.Equal(t, []int{1, 0}, f.Index)
+	assert.Panics(t, func() { v.FieldByIndex(f.Index) })
+
+	// Value and pointer ports share one parse.
+	pv := usecase.PortInfoOf(infoPort{})
+	assert.False(t, pv.IsPtr)
+	assert.Equal(t, p.Type, pv.Type)
+	assert.Same(t, &p.Fields[0], &pv.Fields[0])
+
+	w := usecase.PortInfoOf(new(usecase.OutputWithEmbeddedWriter))
+	assert.True(t, w.WithWriter)
+
+	s := usecase.PortInfoOf("")
+	assert.False(t, s.IsPtr)
+	assert.Equal(t, reflect.TypeOf(""), s.Type)
+	assert.Empty(t, s.Fields)
+
+	assert.Nil(t, usecase.PortInfoOf(nil))
+}
+
+type (
+	resolveC struct {
+		Y string
+	}
+	resolveA struct {
+		resolveC
+	}
+	resolveB struct {
+		Y int
+	}
+	resolveP struct {
+		resolveA
+		resolveB
+	}
+	clashA struct {
+		Z int
+	}
+	clashB struct {
+		Z string
+	}
+	clashP struct {
+		clashA
+		clashB
+	}
+	recursiveP struct {
+		*recursiveP
+		Name string
+	}
+)
+
+func TestPortInfoOf_promotion(t *testing.T) {
+	p := usecase.PortInfoOf(new(resolveP))
+
+	y, found := p.Field("Y")
+	require.True(t, found)
+	assert.Equal(t, reflect.TypeOf(0), y.Type)
+	assert.Equal(t, []int{1, 0}, y.Index)
+
+	_, found = usecase.PortInfoOf(new(clashP)).Field("Z")
+	assert.False(t, found, "ambiguous field must be omitted")
+
+	r := usecase.PortInfoOf(new(recursiveP))
+	require.Len(t, r.Fields, 1)
+	assert.Equal(t, "Name", r.Fields[0].Name)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import "context"
+
+// Progress describes completion of a long-running operation.
+type Progress struct {
+	Done    int64  `json:"done"`
+	Total   int64  `json:"total"`
+	Message string `json:"message,omitempty"`
+}
+
+// ProgressReporter receives progress of a long-running interaction.
+type ProgressReporter interface {
+	Report(done, total int64, message string)
+}
+
+// ProgressFunc makes ProgressReporter from function.
+type ProgressFunc func(p Progress)
+
+// Report implements ProgressReporter.
+func (f ProgressFunc) Report(done, total int64, message string) {
+	f(Progress{Done: done, Total: total, Message: message})
+}
+
+type progressCtxKey struct{}
+
+type progressReporters []ProgressReporter
+
+// Report implements ProgressReporter.
+func (rs progressReporters) Report(done, total int64, message string) {
+	for _, r := range rs {
+		r.Report(done, total, message)
+	}
+}
+
+// WithProgress returns context with progress subscriber.
+//
+// Subscribers of parent context keep receiving reports.
+func WithProgress(ctx context.Context, subscriber ProgressReporter) context.Context {
+	parent, _ := ctx.Value(progressCtxKey{}).(progressReporters) //nolint:errcheck // Missing parent is a valid case.
+
+	rs := make(progressReporters, 0, len(parent)+1)
+	rs = append(rs, parent...)
+	rs = append(rs, subscriber)
+
+	return context.WithValue(ctx, progressCtxKey{}, rs)
+}
+
+// ProgressFromContext returns reporter that notifies subscribers of context.
+//
+// Reporter does nothing if there are no subscribers.
+func ProgressFromContext(ctx context.Context) ProgressReporter {
+	rs, _ := ctx.Value(progressCtxKey{}).(progressReporters) //nolint:errcheck // Missing reporters is a valid case.
+
+	return rs
+}
+
+// ReportProgress notifies progress subscribers of context.
+func ReportProgress(ctx context.Context, done, total int64, message string) {
+	ProgressFromContext(ctx).Report(done, total, message)
+}
+
+// OutputWithProgress defines output with progress reporter.
+type OutputWithProgress interface {
+	SetProgressReporter(r ProgressReporter)
+}
+
+// OutputWithEmbeddedProgress implements progress reporting of use case output.
+type OutputWithEmbeddedProgress struct {
+	reporter ProgressReporter
+}
+
+// SetProgressReporter implements OutputWithProgress.
+func (o *OutputWithEmbeddedProgress) SetProgressReporter(r ProgressReporter) {
+	o.reporter = r
+}
+
+// Report sends progress to reporter, it does nothing if reporter is not set.
+func (o OutputWithEmbeddedProgress) Report(done, total int64, message string) {
+	if o.reporter != nil {
+		o.reporter.Report(done, total, message)
+	}
+}
@@ -0,0 +1,108 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+)
+
+func TestReportProgress(t *testing.T) {
+	ctx := context.Background()
+
+	// No subscribers.
+	usecase.ReportProgress(ctx, 1, 2, "noop")
+
+	var first, second []usecase.Progress
+
+	ctx = usecase.WithProgress(ctx, usecase.ProgressFunc(func(p usecase.Progress) {
+		first = append(first, p)
+	}))
+
+	usecase.ReportProgress(ctx, 1, 3, "started")
+
+	// Middleware can subscribe to progress of wrapped interactor.
+	mw := usecase.MiddlewareFunc(func(next usecase.Interactor) usecase.Interactor {
+		return usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			ctx = usecase.WithProgress(ctx, usecase.ProgressFunc(func(p usecase.Progress) {
+				second = append(second, p)
+			}))
+
+			return next.Interact(ctx, input, output)
+		})
+	})
+
+	u := usecase.Wrap(usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		usecase.ProgressFromContext(ctx).Report(2, 3, "half")
+
+		return nil
+	}), mw)
+
+	require.NoError(t, u.Interact(ctx, nil, nil))
+
+	assert.Equal(t, []usecase.Progress{{Done: 1, Total: 3, Message: "started"}, {Done: 2, Total: 3, Message: "half"}}, first)
+	assert.Equal(t, []usecase.Progress{{Done: 2, Total: 3, Message: "half"}}, second)
+}
+
+type importOutput struct {
+	usecase.OutputWithEmbeddedProgress
+	Imported int
+}
+
+func TestOutputWithEmbeddedProgress(t *testing.T) {
+	out := importOutput{}
+
+	// Reporter is not set.
+	out.Report(1, 2, "noop")
+
+	var reports []usecase.Progress
+
+	var o usecase.OutputWithProgress = &out
+
+	o.SetProgressReporter(usecase.ProgressFunc(func(p usecase.Progress) {
+		reports = append(reports, p)
+	}))
+
+	out.Report(1, 2, "first")
+	assert.Equal(t, []usecase.Progress{{Done: 1, Total: 2, Message: "first"}}, reports)
+}
+
+func TestJobRunner_progress(t *testing.T) {
+	r := usecase.NewJobRunner(1)
+	defer r.Close()
+
+	step := make(chan struct{})
+	reported := make(chan struct{})
+
+	u := usecase.NewIOI(nil, new(importOutput), func(ctx context.Context, input, output interface{}) error {
+		out := output.(*importOutput)
+
+		out.Report(5, 10, "importing")
+		reported <- struct{}{}
+		<-step
+
+		usecase.ReportProgress(ctx, 10, 10, "imported")
+		out.Imported = 10
+
+		return nil
+	})
+
+	id, err := r.Submit(context.Background(), u, nil)
+	require.NoError(t, err)
+
+	<-reported
+
+	job, err := r.Job(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, usecase.JobRunning, job.Status)
+	assert.Equal(t, usecase.Progress{Done: 5, Total: 10, Message: "importing"}, job.Progress)
+
+	close(step)
+
+	job = waitJob(t, r, id)
+	assert.Equal(t, usecase.JobSucceeded, job.Status)
+	assert.Equal(t, usecase.Progress{Done: 10, Total: 10, Message: "imported"}, job.Progress)
+	assert.Equal(t, 10, job.Output.(*importOutput).Imported)
+}
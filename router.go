@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/swaggest/usecase/status"
+)
+
+// ErrPortMismatch is returned when combined interactors have incompatible ports.
+const ErrPortMismatch = sentinelError("port mismatch")
+
+// Route is a conditional branch of router.
+type Route struct {
+	Interactor
+
+	// Match checks if call should be passed to Interactor.
+	Match func(ctx context.Context, input interface{}) bool
+}
+
+// NewRouter creates use case interactor that passes call to the first child with matching route.
+//
+// Calls that match no route are passed to fallback, or fail with status.Unimplemented if fallback is nil.
+// Ports of children must have same types, otherwise ErrPortMismatch is returned.
+// Tags and expected errors of children are combined, status.Unimplemented is added if fallback is nil.
+// It pre-fills name and title with caller function.
+func NewRouter(routes []Route, fallback Interactor, options ...func(i *IOInteractor)) (IOInteractor, error) {
+	u := IOInteractor{}
+
+	children := make([]Interactor, 0, len(routes)+1)
+	for _, r := range routes {
+		children = append(children, r.Interactor)
+	}
+
+	if fallback != nil {
+		children = append(children, fallback)
+	}
+
+	for idx, c := range children {
+		if err := mergePort(&u.Input, inputPort(c)); err != nil {
+			return u, fmt.Errorf("input of %s: %w", stepName(c, idx), err)
+		}
+
+		if err := mergePort(&u.Output, outputPort(c)); err != nil {
+			return u, fmt.Errorf("output of %s: %w", stepName(c, idx), err)
+		}
+
+		mergeInfo(&u.Info, c)
+	}
+
+	if fallback == nil && !hasError(u.expectedErrors, status.Unimplemented) {
+		u.expectedErrors = append(u.expectedErrors, status.Unimplemented)
+	}
+
+	u.Interactor = Interact(func(ctx context.Context, input, output interface{}) error {
+		for _, r := range routes {
+			if r.Match == nil || r.Match(ctx, input) {
+				return r.Interact(ctx, input, output)
+			}
+		}
+
+		if fallback != nil {
+			return fallback.Interact(ctx, input, output)
+		}
+
+		return status.Unimplemented
+	})
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u)
+	}
+
+	return u, nil
+}
+
+// MatchContextValue creates route matcher that checks context value.
+func MatchContextValue(key, value interface{}) func(ctx context.Context, input interface{}) bool {
+	return func(ctx context.Context, _ interface{}) bool {
+		return ctx.Value(key) == value
+	}
+}
+
+// mergePort sets port if it is empty, or checks that port type is the same.
+func mergePort(dst *interface{}, port interface{}) error {
+	if port == nil {
+		return nil
+	}
+
+	if *dst == nil {
+		*dst = port
+
+		return nil
+	}
+
+	if reflect.TypeOf(*dst) != reflect.TypeOf(port) {
+		return fmt.Errorf("%w: %T, expected: %T", ErrPortMismatch, port, *dst)
+	}
+
+	return nil
+}
+
+// mergeInfo adds tags and expected errors of interactor.
+func mergeInfo(info *Info, u Interactor) {
+	var (
+		withTags           HasTags
+		withExpectedErrors HasExpectedErrors
+	)
+
+	if As(u, &withTags) {
+		for _, tag := range withTags.Tags() {
+			if !hasTag(info.tags, tag) {
+				info.tags = append(info.tags, tag)
+			}
+		}
+	}
+
+	if As(u, &withExpectedErrors) {
+		for _, err := range withExpectedErrors.ExpectedErrors() {
+			if !hasError(info.expectedErrors, err) {
+				info.expectedErrors = append(info.expectedErrors, err)
+			}
+		}
+	}
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasError(errs []error, err error) bool {
+	if err == nil || !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+
+	for _, e := range errs {
+		if e == err { //nolint:errorlint,goerr113 // Exact match is needed.
+			return true
+		}
+	}
+
+	return false
+}
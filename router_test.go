@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type tenantKey struct{}
+
+func TestNewRouter(t *testing.T) {
+	variant := func(name string, tags ...string) usecase.IOInteractor {
+		u := usecase.NewIOI(new(int), new(string), func(ctx context.Context, input, output interface{}) error {
+			*output.(*string) = name
+
+			return nil
+		})
+		u.SetTags(tags...)
+		u.SetExpectedErrors(status.InvalidArgument, status.NotFound)
+
+		return u
+	}
+
+	u, err := usecase.NewRouter([]usecase.Route{
+		{
+			Interactor: variant("acme", "tenant"),
+			Match:      usecase.MatchContextValue(tenantKey{}, "acme"),
+		},
+		{
+			Interactor: variant("beta", "tenant", "beta"),
+			Match: func(ctx context.Context, input interface{}) bool {
+				return *input.(*int) > 100
+			},
+		},
+	}, variant("default"), func(i *usecase.IOInteractor) {
+		i.SetDescription("Routed.")
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "swaggest/usecase_test.TestNewRouter", u.Name())
+	assert.Equal(t, "Routed.", u.Description())
+	assert.Equal(t, new(int), u.InputPort())
+	assert.Equal(t, new(string), u.OutputPort())
+	assert.Equal(t, []string{"tenant", "beta"}, u.Tags())
+	assert.Equal(t, []error{status.InvalidArgument, status.NotFound}, u.ExpectedErrors())
+
+	var out string
+
+	in := 1
+	ctx := context.Background()
+
+	require.NoError(t, u.Interact(context.WithValue(ctx, tenantKey{}, "acme"), &in, &out))
+	assert.Equal(t, "acme", out)
+
+	require.NoError(t, u.Interact(ctx, &in, &out))
+	assert.Equal(t, "default", out)
+
+	in = 101
+	require.NoError(t, u.Interact(ctx, &in, &out))
+	assert.Equal(t, "beta", out)
+
+	u, err = usecase.NewRouter([]usecase.Route{
+		{
+			Interactor: variant("acme"),
+			Match:      usecase.MatchContextValue(tenantKey{}, "acme"),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []error{status.InvalidArgument, status.NotFound, status.Unimplemented}, u.ExpectedErrors())
+
+	err = u.Interact(ctx, &in, &out)
+	assert.True(t, errors.Is(err, status.Unimplemented))
+	assert.True(t, usecase.IsExpected(err, u.ExpectedErrors()...))
+}
+
+func TestNewRouter_mismatch(t *testing.T) {
+	noop := func(ctx context.Context, input, output interface{}) error { return nil }
+
+	a := usecase.NewIOI(new(int), new(string), noop)
+	a.SetName("a")
+
+	b := usecase.NewIOI(new(string), new(string), noop)
+	b.SetName("b")
+
+	c := usecase.NewIOI(nil, new(int), noop)
+	c.SetName("c")
+
+	_, err := usecase.NewRouter([]usecase.Route{{Interactor: a}}, b)
+	assert.True(t, errors.Is(err, usecase.ErrPortMismatch))
+	assert.EqualError(t, err, "input of b: port mismatch: *string, expected: *int")
+
+	_, err = usecase.NewRouter([]usecase.Route{{Interactor: a}, {Interactor: c}}, nil)
+	assert.EqualError(t, err, "output of c: port mismatch: *int, expected: *string")
+}
@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/usecase/status"
+)
+
+// SagaStatus is a state of saga execution.
+type SagaStatus string
+
+// Saga statuses.
+const (
+	SagaRunning      = SagaStatus("running")
+	SagaCompensating = SagaStatus("compensating")
+	SagaCompleted    = SagaStatus("completed")
+	SagaCompensated  = SagaStatus("compensated")
+	SagaFailed       = SagaStatus("failed")
+)
+
+// SagaStep is a step of Saga with action and optional compensation.
+//
+// Action and compensation receive input and output of saga.
+type SagaStep struct {
+	// Name is used in errors, name of Action use case is used if empty.
+	Name string
+
+	Action       Interactor
+	Compensation Interactor
+}
+
+// SagaState is a persisted progress of saga execution.
+type SagaState struct {
+	ID     string     `json:"id"`
+	Status SagaStatus `json:"status"`
+
+	// Completed is a number of steps with action done and not yet compensated.
+	Completed int `json:"completed"`
+
+	Input  json.RawMessage `json:"input,omitempty"`
+	Output json.RawMessage `json:"output,omitempty"`
+
+	// Error and StatusCode describe failure of an action.
+	Error      string      `json:"error,omitempty"`
+	StatusCode status.Code `json:"statusCode,omitempty"`
+}
+
+// SagaStore persists saga states.
+type SagaStore interface {
+	SaveSaga(ctx context.Context, state SagaState) error
+
+	// LoadSaga fails with status.NotFound if saga does not exist.
+	LoadSaga(ctx context.Context, id string) (SagaState, error)
+
+	// PendingSagas returns IDs of sagas in SagaRunning or SagaCompensating statuses.
+	PendingSagas(ctx context.Context) ([]string, error)
+}
+
+// Saga is an Interactor that runs action of each step sequentially, and on failure
+// runs compensations of completed steps in reverse order.
+type Saga struct {
+	Steps []SagaStep
+
+	// Store persists progress to allow Resume, optional.
+	Store SagaStore
+}
+
+// NewSaga creates use case interactor with input and output ports from Saga.
+//
+// It pre-fills name and title with caller function.
+func NewSaga(input, output interface{}, s Saga, options ...func(i *IOInteractor)) IOInteractor {
+	u := IOInteractor{}
+	u.Input = input
+	u.Output = output
+	u.Interactor = s
+
+	u.name, u.title = callerFunc()
+	u.name = filterName(u.name)
+
+	for _, o := range options {
+		o(&u)
+	}
+
+	return u
+}
+
+type sagaIDCtxKey struct{}
+
+// WithSagaID returns context with saga ID to be used by Saga.Interact.
+func WithSagaID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, sagaIDCtxKey{}, id)
+}
+
+// Interact implements Interactor.
+//
+// Saga ID is taken from context (see WithSagaID), or generated.
+func (s Saga) Interact(ctx context.Context, input, output interface{}) error {
+	id, _ := ctx.Value(sagaIDCtxKey{}).(string) //nolint:errcheck // Empty ID is replaced with generated one.
+	if id == "" {
+		id = newID()
+	}
+
+	return s.Run(ctx, id, input, output)
+}
+
+// Run executes saga with ID.
+//
+// Failure of an action is returned as SagaError.
+func (s Saga) Run(ctx context.Context, id string, input, output interface{}) error {
+	state := SagaState{
+		ID:     id,
+		Status: SagaRunning,
+	}
+
+	if s.Store != nil {
+		in, err := json.Marshal(input)
+		if err != nil {
+			return fmt.Errorf("saga %s: encoding input: %w", id, err)
+		}
+
+		state.Input = in
+	}
+
+	if err := s.save(ctx, &state, nil); err != nil {
+		return err
+	}
+
+	return s.run(ctx, state, input, output)
+}
+
+// Resume continues interrupted saga with ID from Store.
+//
+// Input and output are populated from stored state.
+// Saga that is already finished is not executed again, its stored failure is returned.
+func (s Saga) Resume(ctx context.Context, id string, input, output interface{}) error {
+	if s.Store == nil {
+		return fmt.Errorf("saga %s: %w: store is not configured", id, status.FailedPrecondition)
+	}
+
+	state, err := s.Store.LoadSaga(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(state.Input) > 0 && input != nil {
+		if err := json.Unmarshal(state.Input, input); err != nil {
+			return fmt.Errorf("saga %s: decoding input: %w", id, err)
+		}
+	}
+
+	if len(state.Output) > 0 && output != nil {
+		if err := json.Unmarshal(state.Output, output); err != nil {
+			return fmt.Errorf("saga %s: decoding output: %w", id, err)
+		}
+	}
+
+	switch state.Status {
+	case SagaRunning:
+		return s.run(ctx, state, input, output)
+	case SagaCompensating:
+		return s.compensate(ctx, state, s.stateError(state), input, output)
+	case SagaCompleted:
+		return nil
+	default:
+		return s.stateError(state)
+	}
+}
+
+func (s Saga) run(ctx context.Context, state SagaState, input, output interface{}) error {
+	for i := state.Completed; i < len(s.Steps); i++ {
+		step := s.Steps[i]
+
+		if err := step.Action.Interact(ctx, input, output); err != nil {
+			sagaErr := SagaError{Step: step.name(), Err: err}
+
+			// Action may fail because of canceled context, rollback must still be done and saved.
+			ctx = detachedContext{parent: ctx}
+
+			state.Status = SagaCompensating
+			state.Error = sagaErr.Error()
+			state.StatusCode = sagaErr.Status()
+
+			if err := s.save(ctx, &state, output); err != nil {
+				return err
+			}
+
+			return s.compensate(ctx, state, sagaErr, input, output)
+		}
+
+		state.Completed = i + 1
+		if state.Completed == len(s.Steps) {
+			state.Status = SagaCompleted
+		}
+
+		if err := s.save(ctx, &state, output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compensate runs compensations on context without cancellation of ctx.
+func (s Saga) compensate(ctx context.Context, state SagaState, sagaErr SagaError, input, output interface{}) error {
+	ctx = detachedContext{parent: ctx}
+
+	for i := state.Completed - 1; i >= 0; i-- {
+		step := s.Steps[i]
+
+		if step.Compensation != nil {
+			if err := step.Compensation.Interact(ctx, input, output); err != nil {
+				sagaErr.Compensations = append(sagaErr.Compensations, SagaCompensationError{
+					Step: step.name(),
+					Err:  err,
+				})
+			}
+		}
+
+		state.Completed = i
+
+		if err := s.save(ctx, &state, output); err != nil {
+			return err
+		}
+	}
+
+	state.Status = SagaCompensated
+	if len(sagaErr.Compensations) > 0 {
+		state.Status = SagaFailed
+		state.Error = sagaErr.Error()
+	}
+
+	if err := s.save(ctx, &state, output); err != nil {
+		return err
+	}
+
+	return sagaErr
+}
+
+// stateError restores failure of an action from saga state.
+func (s Saga) stateError(state SagaState) SagaError {
+	return SagaError{
+		Err: storedError{msg: state.Error, code: state.StatusCode},
+	}
+}
+
+// storedError is an error restored from its message and status code.
+type storedError struct {
+	msg  string
+	code status.Code
+}
+
+func (e storedError) Error() string {
+	return e.msg
+}
+
+func (e storedError) Status() status.Code {
+	return e.code
+}
+
+func (e storedError) Is(target error) bool {
+	return e.code != 0 && target == e.code //nolint:goerr113 // Target is expected to be plain status error.
+}
+
+func (s Saga) save(ctx context.Context, state *SagaState, output interface{}) error {
+	if s.Store == nil {
+		return nil
+	}
+
+	if output != nil {
+		out, err := json.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("saga %s: encoding output: %w", state.ID, err)
+		}
+
+		state.Output = out
+	}
+
+	if err := s.Store.SaveSaga(ctx, *state); err != nil {
+		return fmt.Errorf("saga %s: saving state: %w", state.ID, err)
+	}
+
+	return nil
+}
+
+func (s SagaStep) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	var withName HasName
+
+	if As(s.Action, &withName) {
+		return withName.Name()
+	}
+
+	return ""
+}
+
+// SagaError describes failed action of saga and errors of compensations.
+type SagaError struct {
+	// Step is a name of failed step, it is empty for saga restored from store.
+	Step string
+	Err  error
+
+	Compensations []SagaCompensationError
+}
+
+// Error returns error message.
+func (e SagaError) Error() string {
+	msg := e.Err.Error()
+	if e.Step != "" {
+		msg = e.Step + ": " + msg
+	}
+
+	if len(e.Compensations) == 0 {
+		return msg
+	}
+
+	comp := make([]string, 0, len(e.Compensations))
+	for _, c := range e.Compensations {
+		comp = append(comp, c.Error())
+	}
+
+	return msg + " (compensation failed: " + strings.Join(comp, "; ") + ")"
+}
+
+// Status returns status code of failed action.
+func (e SagaError) Status() status.Code {
+	if code := StatusOf(e.Err); code != 0 {
+		return code
+	}
+
+	return status.Unknown
+}
+
+// Unwrap returns error of failed action.
+func (e SagaError) Unwrap() error {
+	return e.Err
+}
+
+// SagaCompensationError describes failed compensation of a saga step.
+type SagaCompensationError struct {
+	Step string
+	Err  error
+}
+
+// Error returns error message.
+func (e SagaCompensationError) Error() string {
+	if e.Step == "" {
+		return e.Err.Error()
+	}
+
+	return e.Step + ": " + e.Err.Error()
+}
+
+// Status returns status code of compensation error.
+func (e SagaCompensationError) Status() status.Code {
+	if code := StatusOf(e.Err); code != 0 {
+		return code
+	}
+
+	return status.Unknown
+}
+
+// Unwrap returns compensation error.
+func (e SagaCompensationError) Unwrap() error {
+	return e.Err
+}
+
+// InMemorySagaStore is a SagaStore that keeps states in memory.
+//
+// Zero value is ready to use.
+type InMemorySagaStore struct {
+	mu     sync.Mutex
+	states map[string]SagaState
+}
+
+var _ SagaStore = &InMemorySagaStore{}
+
+// SaveSaga implements SagaStore.
+func (m *InMemorySagaStore) SaveSaga(_ context.Context, state SagaState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.states == nil {
+		m.states = make(map[string]SagaState)
+	}
+
+	m.states[state.ID] = state
+
+	return nil
+}
+
+// LoadSaga implements SagaStore.
+func (m *InMemorySagaStore) LoadSaga(_ context.Context, id string) (SagaState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, found := m.states[id]
+	if !found {
+		return state, fmt.Errorf("saga %s: %w", id, status.NotFound)
+	}
+
+	return state, nil
+}
+
+// PendingSagas implements SagaStore.
+func (m *InMemorySagaStore) PendingSagas(_ context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ids []string
+
+	for id, state := range m.states {
+		if state.Status == SagaRunning || state.Status == SagaCompensating {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// newID returns random identifier.
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
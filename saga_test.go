@@ -0,0 +1,249 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type orderInput struct {
+	OrderID int `json:"orderId"`
+}
+
+type orderOutput struct {
+	Log []string `json:"log"`
+}
+
+func sagaStep(name string, actionErr, compensationErr error) usecase.SagaStep {
+	return usecase.SagaStep{
+		Name: name,
+		Action: usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			out := output.(*orderOutput)
+			out.Log = append(out.Log, name)
+
+			return actionErr
+		}),
+		Compensation: usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			out := output.(*orderOutput)
+			out.Log = append(out.Log, "undo "+name)
+
+			return compensationErr
+		}),
+	}
+}
+
+func TestNewSaga(t *testing.T) {
+	store := &usecase.InMemorySagaStore{}
+
+	u := usecase.NewSaga(new(orderInput), new(orderOutput), usecase.Saga{
+		Steps: []usecase.SagaStep{
+			sagaStep("reserve", nil, nil),
+			sagaStep("charge", nil, nil),
+		},
+		Store: store,
+	})
+	assert.Equal(t, "swaggest/usecase_test.TestNewSaga", u.Name())
+
+	ctx := usecase.WithSagaID(context.Background(), "saga1")
+	out := orderOutput{}
+
+	require.NoError(t, u.Interact(ctx, &orderInput{OrderID: 1}, &out))
+	assert.Equal(t, []string{"reserve", "charge"}, out.Log)
+
+	state, err := store.LoadSaga(ctx, "saga1")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.SagaCompleted, state.Status)
+	assert.Equal(t, 2, state.Completed)
+	assert.JSONEq(t, `{"orderId":1}`, string(state.Input))
+	assert.JSONEq(t, `{"log":["reserve","charge"]}`, string(state.Output))
+}
+
+func TestSaga_Run_compensation(t *testing.T) {
+	store := &usecase.InMemorySagaStore{}
+	ctx := context.Background()
+
+	s := usecase.Saga{
+		Steps: []usecase.SagaStep{
+			sagaStep("reserve", nil, nil),
+			sagaStep("notify", nil, status.Wrap(errors.New("smtp failure"), status.Unavailable)),
+			sagaStep("charge", status.Wrap(errors.New("insufficient funds"), status.FailedPrecondition), nil),
+			sagaStep("ship", nil, nil),
+		},
+		Store: store,
+	}
+
+	out := orderOutput{}
+	err := s.Run(ctx, "saga2", &orderInput{OrderID: 2}, &out)
+
+	assert.Equal(t, []string{"reserve", "notify", "charge", "undo notify", "undo reserve"}, out.Log)
+	assert.EqualError(t, err, "charge: failed precondition: insufficient funds "+
+		"(compensation failed: notify: unavailable: smtp failure)")
+	assert.True(t, errors.Is(err, status.FailedPrecondition))
+
+	var se usecase.SagaError
+
+	require.True(t, errors.As(err, &se))
+	assert.Equal(t, "charge", se.Step)
+	assert.Equal(t, status.FailedPrecondition, se.Status())
+	require.Len(t, se.Compensations, 1)
+	assert.Equal(t, status.Unavailable, se.Compensations[0].Status())
+	assert.True(t, errors.Is(se.Compensations[0], status.Unavailable))
+
+	state, err := store.LoadSaga(ctx, "saga2")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.SagaFailed, state.Status)
+	assert.Equal(t, 0, state.Completed)
+	assert.Equal(t, status.FailedPrecondition, state.StatusCode)
+
+	// Finished saga is not executed again.
+	out = orderOutput{}
+	err = s.Resume(ctx, "saga2", new(orderInput), &out)
+	assert.True(t, errors.Is(err, status.FailedPrecondition))
+	assert.Equal(t, []string{"reserve", "notify", "charge", "undo notify", "undo reserve"}, out.Log)
+
+	ids, err := store.PendingSagas(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestSaga_Resume(t *testing.T) {
+	store := &usecase.InMemorySagaStore{}
+	ctx := context.Background()
+
+	// Saga was interrupted after the first step.
+	require.NoError(t, store.SaveSaga(ctx, usecase.SagaState{
+		ID:        "saga3",
+		Status:    usecase.SagaRunning,
+		Completed: 1,
+		Input:     []byte(`{"orderId":3}`),
+		Output:    []byte(`{"log":["reserve"]}`),
+	}))
+
+	// Saga was interrupted during compensation.
+	require.NoError(t, store.SaveSaga(ctx, usecase.SagaState{
+		ID:         "saga4",
+		Status:     usecase.SagaCompensating,
+		Completed:  1,
+		Input:      []byte(`{"orderId":4}`),
+		Output:     []byte(`{"log":["reserve","charge"]}`),
+		Error:      "charge: not found: card not found",
+		StatusCode: status.NotFound,
+	}))
+
+	ids, err := store.PendingSagas(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"saga3", "saga4"}, ids)
+
+	s := usecase.Saga{
+		Steps: []usecase.SagaStep{
+			sagaStep("reserve", nil, nil),
+			sagaStep("charge", nil, nil),
+		},
+		Store: store,
+	}
+
+	in := orderInput{}
+	out := orderOutput{}
+
+	require.NoError(t, s.Resume(ctx, "saga3", &in, &out))
+	assert.Equal(t, 3, in.OrderID)
+	assert.Equal(t, []string{"reserve", "charge"}, out.Log)
+
+	out = orderOutput{}
+	err = s.Resume(ctx, "saga4", &in, &out)
+	assert.EqualError(t, err, "charge: not found: card not found")
+	assert.True(t, errors.Is(err, status.NotFound))
+	assert.Equal(t, status.NotFound, err.(usecase.SagaError).Status())
+	assert.Equal(t, []string{"reserve", "charge", "undo reserve"}, out.Log)
+
+	state, err := store.LoadSaga(ctx, "saga4")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.SagaCompensated, state.Status)
+
+	require.NoError(t, s.Resume(ctx, "saga3", &in, &out))
+
+	err = s.Resume(ctx, "missing", &in, &out)
+	assert.True(t, errors.Is(err, status.NotFound))
+
+	s.Store = nil
+	err = s.Resume(ctx, "saga3", &in, &out)
+	assert.True(t, errors.Is(err, status.FailedPrecondition))
+}
+
+func TestSaga_Interact_noStore(t *testing.T) {
+	s := usecase.Saga{
+		Steps: []usecase.SagaStep{
+			sagaStep("reserve", nil, nil),
+			{
+				Action: usecase.NewIOI(nil, nil, func(ctx context.Context, input, output interface{}) error {
+					return errors.New("failed")
+				}),
+			},
+		},
+	}
+
+	out := orderOutput{}
+	err := s.Interact(context.Background(), nil, &out)
+	assert.EqualError(t, err, "swaggest/usecase_test.TestSaga_Interact_noStore: failed")
+	assert.Equal(t, status.Unknown, err.(usecase.SagaError).Status())
+	assert.Equal(t, []string{"reserve", "undo reserve"}, out.Log)
+}
+
+type sagaCtxKey struct{}
+
+// ctxSagaStep fails action and compensation with context error, action also cancels context.
+func ctxSagaStep(name string, cancel context.CancelFunc) usecase.SagaStep {
+	return usecase.SagaStep{
+		Name: name,
+		Action: usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			out := output.(*orderOutput)
+			out.Log = append(out.Log, name)
+
+			if cancel != nil {
+				cancel()
+			}
+
+			return ctx.Err()
+		}),
+		Compensation: usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			out := output.(*orderOutput)
+			out.Log = append(out.Log, "undo "+name+" for "+ctx.Value(sagaCtxKey{}).(string))
+
+			return ctx.Err()
+		}),
+	}
+}
+
+func TestSaga_Run_canceled(t *testing.T) {
+	store := &usecase.InMemorySagaStore{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), sagaCtxKey{}, "jane"))
+
+	s := usecase.Saga{
+		Steps: []usecase.SagaStep{
+			ctxSagaStep("reserve", nil),
+			ctxSagaStep("charge", cancel),
+		},
+		Store: store,
+	}
+
+	out := orderOutput{}
+	err := s.Run(ctx, "saga5", &orderInput{OrderID: 5}, &out)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, []string{"reserve", "charge", "undo reserve for jane"}, out.Log)
+
+	var se usecase.SagaError
+
+	require.True(t, errors.As(err, &se))
+	assert.Empty(t, se.Compensations)
+
+	state, err := store.LoadSaga(context.Background(), "saga5")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.SagaCompensated, state.Status)
+	assert.Equal(t, 0, state.Completed)
+}
@@ -0,0 +1,263 @@
+//go:build go1.18
+// +build go1.18
+
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/swaggest/usecase/status"
+)
+
+// ErrStreamClosed is returned when item is sent to closed stream.
+const ErrStreamClosed = sentinelError("stream closed")
+
+// StreamSink receives items of a typed stream.
+//
+// Send blocks until item is accepted, this provides backpressure to producer.
+type StreamSink[T any] interface {
+	Send(ctx context.Context, item T) error
+}
+
+// StreamSinkFunc makes StreamSink from function.
+type StreamSinkFunc[T any] func(ctx context.Context, item T) error
+
+// Send implements StreamSink.
+func (f StreamSinkFunc[T]) Send(ctx context.Context, item T) error {
+	return f(ctx, item)
+}
+
+// OutputWithStream is an embeddable use case output that emits typed items one at a time.
+//
+// Stream ends when interaction returns, error of interaction terminates stream.
+type OutputWithStream[T any] struct {
+	sink StreamSink[T]
+}
+
+var _ OutputWithItemStream = &OutputWithStream[int]{}
+
+// SetStreamSink sets receiver of stream items.
+func (o *OutputWithStream[T]) SetStreamSink(s StreamSink[T]) {
+	o.sink = s
+}
+
+// SetItemSender implements OutputWithItemStream.
+func (o *OutputWithStream[T]) SetItemSender(send func(ctx context.Context, item interface{}) error) {
+	o.sink = StreamSinkFunc[T](func(ctx context.Context, item T) error {
+		return send(ctx, item)
+	})
+}
+
+// StreamItem implements OutputWithItemStream.
+func (o OutputWithStream[T]) StreamItem() interface{} {
+	return *new(T)
+}
+
+// Send emits stream item, it blocks until item is accepted by receiver.
+//
+// It fails with status.FailedPrecondition if receiver is not set.
+func (o OutputWithStream[T]) Send(ctx context.Context, item T) error {
+	if o.sink == nil {
+		return fmt.Errorf("stream receiver is not set: %w", status.FailedPrecondition)
+	}
+
+	return o.sink.Send(ctx, item)
+}
+
+// Stream is a channel-backed StreamSink with consumer side.
+//
+// Producer calls Send and then Close, consumer calls Next until it returns an error.
+type Stream[T any] struct {
+	items chan T
+	mu    sync.Mutex
+	err   error
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewStream creates stream with buffer size, zero buffer makes every Send wait for Next.
+func NewStream[T any](buffer int) *Stream[T] {
+	return &Stream[T]{
+		items: make(chan T, buffer),
+		done:  make(chan struct{}),
+	}
+}
+
+// Send implements StreamSink.
+func (s *Stream[T]) Send(ctx context.Context, item T) error {
+	select {
+	case <-s.done:
+		return ErrStreamClosed
+	default:
+	}
+
+	select {
+	case s.items <- item:
+		return nil
+	case <-s.done:
+		return ErrStreamClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close ends stream, nil error means successful end of stream.
+//
+// Items that are already sent remain available to consumer.
+func (s *Stream[T]) Close(err error) {
+	s.once.Do(func() {
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+
+		close(s.done)
+	})
+}
+
+// Next returns next stream item.
+//
+// It fails with io.EOF at the end of successful stream, or with error of Close.
+func (s *Stream[T]) Next(ctx context.Context) (T, error) {
+	select {
+	case item := <-s.items:
+		return item, nil
+	default:
+	}
+
+	select {
+	case item := <-s.items:
+		return item, nil
+	case <-s.done:
+		// Drain items sent before Close.
+		select {
+		case item := <-s.items:
+			return item, nil
+		default:
+		}
+
+		s.mu.Lock()
+		err := s.err
+		s.mu.Unlock()
+
+		if err == nil {
+			err = io.EOF
+		}
+
+		return *new(T), err
+	case <-ctx.Done():
+		return *new(T), ctx.Err()
+	}
+}
+
+// StreamCollector is a StreamSink that collects items, for example in tests.
+type StreamCollector[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+// Send implements StreamSink.
+func (c *StreamCollector[T]) Send(_ context.Context, item T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = append(c.items, item)
+
+	return nil
+}
+
+// Items returns collected items.
+func (c *StreamCollector[T]) Items() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]T(nil), c.items...)
+}
+
+// CollectStream invokes interactor with streaming output and returns emitted items.
+func CollectStream[T any](ctx context.Context, u Interactor, input interface{}, output interface {
+	SetStreamSink(s StreamSink[T])
+},
+) ([]T, error) {
+	c := &StreamCollector[T]{}
+	output.SetStreamSink(c)
+
+	err := u.Interact(ctx, input, output)
+
+	return c.Items(), err
+}
+
+// StreamSource provides items of a typed stream.
+//
+// Next returns io.EOF at the end of stream.
+type StreamSource[T any] interface {
+	Next(ctx context.Context) (T, error)
+}
+
+// StreamSourceFunc makes StreamSource from function.
+type StreamSourceFunc[T any] func(ctx context.Context) (T, error)
+
+// Next implements StreamSource.
+func (f StreamSourceFunc[T]) Next(ctx context.Context) (T, error) {
+	return f(ctx)
+}
+
+// InputWithStream is an embeddable use case input that consumes typed items one at a time.
+type InputWithStream[T any] struct {
+	source StreamSource[T]
+}
+
+var _ InputWithItemStream = &InputWithStream[int]{}
+
+// SetStreamSource sets provider of stream items.
+func (i *InputWithStream[T]) SetStreamSource(s StreamSource[T]) {
+	i.source = s
+}
+
+// SetItemSource implements InputWithItemStream.
+func (i *InputWithStream[T]) SetItemSource(next func(ctx context.Context) (interface{}, error)) {
+	i.source = StreamSourceFunc[T](func(ctx context.Context) (T, error) {
+		item, err := next(ctx)
+		if err != nil {
+			return *new(T), err
+		}
+
+		v, ok := item.(T)
+		if !ok {
+			return *new(T), fmt.Errorf("%w of stream item: %T, expected: %T", ErrInvalidType, item, *new(T))
+		}
+
+		return v, nil
+	})
+}
+
+// StreamItem implements InputWithItemStream.
+func (i InputWithStream[T]) StreamItem() interface{} {
+	return *new(T)
+}
+
+// Next returns next stream item, it fails with io.EOF at the end of stream.
+//
+// It fails with status.FailedPrecondition if source is not set.
+func (i InputWithStream[T]) Next(ctx context.Context) (T, error) {
+	if i.source == nil {
+		return *new(T), fmt.Errorf("stream source is not set: %w", status.FailedPrecondition)
+	}
+
+	return i.source.Next(ctx)
+}
+
+// StreamOf creates closed Stream with items, for example to feed InputWithStream in tests.
+func StreamOf[T any](items ...T) *Stream[T] {
+	s := NewStream[T](len(items))
+
+	for _, item := range items {
+		s.items <- item
+	}
+
+	s.Close(nil)
+
+	return s
+}
@@ -0,0 +1,199 @@
+//go:build go1.18
+// +build go1.18
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type event struct {
+	ID int `json:"id"`
+}
+
+type eventsOutput struct {
+	usecase.OutputWithStream[event]
+}
+
+func newEventsInteractor() usecase.IOInteractorOf[int, eventsOutput] {
+	return usecase.NewInteractor(func(ctx context.Context, input int, output *eventsOutput) error {
+		for i := 1; i <= input; i++ {
+			if err := output.Send(ctx, event{ID: i}); err != nil {
+				return err
+			}
+		}
+
+		if input > 3 {
+			return status.ResourceExhausted
+		}
+
+		return nil
+	})
+}
+
+func TestCollectStream(t *testing.T) {
+	u := newEventsInteractor()
+	ctx := context.Background()
+
+	items, err := usecase.CollectStream[event](ctx, u, 3, &eventsOutput{})
+	require.NoError(t, err)
+	assert.Equal(t, []event{{ID: 1}, {ID: 2}, {ID: 3}}, items)
+
+	items, err = usecase.CollectStream[event](ctx, u, 4, &eventsOutput{})
+	assert.True(t, errors.Is(err, status.ResourceExhausted))
+	assert.Len(t, items, 4)
+
+	// Receiver is not set.
+	err = u.Interact(ctx, 1, &eventsOutput{})
+	assert.True(t, errors.Is(err, status.FailedPrecondition))
+}
+
+func TestOutputWithStream_SetItemSender(t *testing.T) {
+	out := &eventsOutput{}
+
+	var (
+		o     usecase.OutputWithItemStream = out
+		items []interface{}
+	)
+
+	assert.Equal(t, event{}, o.StreamItem())
+
+	o.SetItemSender(func(ctx context.Context, item interface{}) error {
+		items = append(items, item)
+
+		return nil
+	})
+
+	require.NoError(t, newEventsInteractor().Interact(context.Background(), 2, out))
+	assert.Equal(t, []interface{}{event{ID: 1}, event{ID: 2}}, items)
+}
+
+func TestStream(t *testing.T) {
+	u := newEventsInteractor()
+	ctx := context.Background()
+
+	consume := func(n int) ([]event, error) {
+		s := usecase.NewStream[event](0)
+		out := &eventsOutput{}
+		out.SetStreamSink(s)
+
+		go func() {
+			s.Close(u.Interact(ctx, n, out))
+		}()
+
+		var items []event
+
+		for {
+			item, err := s.Next(ctx)
+			if err != nil {
+				return items, err
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	items, err := consume(3)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []event{{ID: 1}, {ID: 2}, {ID: 3}}, items)
+
+	items, err = consume(5)
+	assert.True(t, errors.Is(err, status.ResourceExhausted))
+	assert.Len(t, items, 5)
+}
+
+func TestStream_Close(t *testing.T) {
+	ctx := context.Background()
+	s := usecase.NewStream[int](2)
+
+	require.NoError(t, s.Send(ctx, 1))
+	s.Close(nil)
+	s.Close(errors.New("ignored"))
+
+	assert.True(t, errors.Is(s.Send(ctx, 2), usecase.ErrStreamClosed))
+
+	item, err := s.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, item)
+
+	_, err = s.Next(ctx)
+	assert.Equal(t, io.EOF, err)
+
+	// Backpressure is released by context cancellation.
+	s = usecase.NewStream[int](0)
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	assert.True(t, errors.Is(s.Send(cctx, 1), context.Canceled))
+
+	_, err = s.Next(cctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+type importInput struct {
+	usecase.InputWithStream[event]
+	Source string `query:"source"`
+}
+
+func TestInputWithStream(t *testing.T) {
+	u := usecase.NewInteractor(func(ctx context.Context, input importInput, output *[]int) error {
+		for {
+			item, err := input.Next(ctx)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			*output = append(*output, item.ID)
+		}
+	})
+
+	assert.True(t, usecase.IsStreaming(u.InputPort()))
+	assert.Equal(t, event{}, importInput{}.StreamItem())
+
+	ctx := context.Background()
+	in := importInput{}
+	in.SetStreamSource(usecase.StreamOf(event{ID: 1}, event{ID: 2}))
+
+	var out []int
+
+	require.NoError(t, u.Invoke(ctx, in, &out))
+	assert.Equal(t, []int{1, 2}, out)
+
+	// Untyped source.
+	items := []interface{}{event{ID: 3}, "bad"}
+	in = importInput{}
+
+	var i usecase.InputWithItemStream = &in
+
+	i.SetItemSource(func(ctx context.Context) (interface{}, error) {
+		if len(items) == 0 {
+			return nil, io.EOF
+		}
+
+		item := items[0]
+		items = items[1:]
+
+		return item, nil
+	})
+
+	out = nil
+	err := u.Invoke(ctx, in, &out)
+	assert.True(t, errors.Is(err, usecase.ErrInvalidType))
+	assert.Equal(t, []int{3}, out)
+
+	// Source is not set.
+	err = u.Invoke(ctx, importInput{}, &out)
+	assert.True(t, errors.Is(err, status.FailedPrecondition))
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrInvalidEventName is returned when event name can not be written to event stream.
+const ErrInvalidEventName = sentinelError("invalid event name")
+
+// Content types of streaming outputs.
+const (
+	ContentTypeEventStream = "text/event-stream"
+	ContentTypeNDJSON      = "application/x-ndjson"
+)
+
+// HasStreamContentType declares content type of streaming output.
+type HasStreamContentType interface {
+	StreamContentType() string
+}
+
+// EventWriter writes named events.
+type EventWriter interface {
+	WriteEvent(name string, data interface{}) error
+}
+
+// JSONEncoder writes JSON values.
+type JSONEncoder interface {
+	Encode(v interface{}) error
+}
+
+// SSEWriter writes server-sent events.
+type SSEWriter struct {
+	w io.Writer
+}
+
+var (
+	_ EventWriter          = &SSEWriter{}
+	_ HasStreamContentType = &SSEWriter{}
+)
+
+// NewSSEWriter creates server-sent events writer.
+func NewSSEWriter(w io.Writer) *SSEWriter {
+	return &SSEWriter{w: w}
+}
+
+// StreamContentType implements HasStreamContentType.
+func (s *SSEWriter) StreamContentType() string {
+	return ContentTypeEventStream
+}
+
+// WriteEvent writes event and flushes underlying writer.
+//
+// String and []byte data is written as is, other values are encoded as JSON.
+// Empty name omits event field, so that event has default "message" type.
+// Name must not contain line breaks, otherwise ErrInvalidEventName is returned.
+func (s *SSEWriter) WriteEvent(name string, data interface{}) error {
+	if strings.ContainsAny(name, "\r\n") {
+		return fmt.Errorf("%w: %q", ErrInvalidEventName, name)
+	}
+
+	var payload string
+
+	switch v := data.(type) {
+	case string:
+		payload = v
+	case []byte:
+		payload = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		payload = string(b)
+	}
+
+	var buf bytes.Buffer
+
+	if name != "" {
+		buf.WriteString("event: " + name + "\n")
+	}
+
+	// Any of CRLF, LF and CR ends a line in event stream.
+	payload = strings.ReplaceAll(strings.ReplaceAll(payload, "\r\n", "\n"), "\r", "\n")
+
+	for _, line := range strings.Split(payload, "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+
+	buf.WriteString("\n")
+
+	if _, err := s.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	return flush(s.w)
+}
+
+// Flush flushes underlying writer if it supports flushing.
+func (s *SSEWriter) Flush() error {
+	return flush(s.w)
+}
+
+// NDJSONWriter writes newline-delimited JSON values.
+type NDJSONWriter struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+var (
+	_ JSONEncoder          = &NDJSONWriter{}
+	_ HasStreamContentType = &NDJSONWriter{}
+)
+
+// NewNDJSONWriter creates newline-delimited JSON writer.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+// StreamContentType implements HasStreamContentType.
+func (n *NDJSONWriter) StreamContentType() string {
+	return ContentTypeNDJSON
+}
+
+// Encode writes JSON value as a line and flushes underlying writer.
+func (n *NDJSONWriter) Encode(v interface{}) error {
+	if err := n.enc.Encode(v); err != nil {
+		return err
+	}
+
+	return flush(n.w)
+}
+
+// Flush flushes underlying writer if it supports flushing.
+func (n *NDJSONWriter) Flush() error {
+	return flush(n.w)
+}
+
+// flush calls Flush of http.Flusher or bufio.Writer.
+func flush(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+
+	return nil
+}
+
+// OutputWithEventStream is an embeddable use case output that writes server-sent events.
+type OutputWithEventStream struct {
+	*SSEWriter
+}
+
+var (
+	_ OutputWithWriter     = &OutputWithEventStream{}
+	_ HasStreamContentType = OutputWithEventStream{}
+)
+
+// SetWriter implements OutputWithWriter.
+func (o *OutputWithEventStream) SetWriter(w io.Writer) {
+	o.SSEWriter = NewSSEWriter(w)
+}
+
+// StreamContentType implements HasStreamContentType.
+func (o OutputWithEventStream) StreamContentType() string {
+	return ContentTypeEventStream
+}
+
+// OutputWithNDJSON is an embeddable use case output that writes newline-delimited JSON values.
+type OutputWithNDJSON struct {
+	*NDJSONWriter
+}
+
+var (
+	_ OutputWithWriter     = &OutputWithNDJSON{}
+	_ HasStreamContentType = OutputWithNDJSON{}
+)
+
+// SetWriter implements OutputWithWriter.
+func (o *OutputWithNDJSON) SetWriter(w io.Writer) {
+	o.NDJSONWriter = NewNDJSONWriter(w)
+}
+
+// StreamContentType implements HasStreamContentType.
+func (o OutputWithNDJSON) StreamContentType() string {
+	return ContentTypeNDJSON
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+)
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushed int
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushed++
+}
+
+func TestSSEWriter_WriteEvent(t *testing.T) {
+	w := &flushRecorder{}
+	s := usecase.NewSSEWriter(w)
+
+	assert.Equal(t, usecase.ContentTypeEventStream, s.StreamContentType())
+
+	require.NoError(t, s.WriteEvent("greeting", "hello\nworld"))
+	require.NoError(t, s.WriteEvent("", []byte("ping")))
+	require.NoError(t, s.WriteEvent("item", map[string]int{"id": 1}))
+	assert.Error(t, s.WriteEvent("bad", func() {}))
+	assert.ErrorIs(t, s.WriteEvent("bad\ndata: injected", "x"), usecase.ErrInvalidEventName)
+	assert.ErrorIs(t, s.WriteEvent("bad\r", "x"), usecase.ErrInvalidEventName)
+	require.NoError(t, s.WriteEvent("", "a\rb"))
+	require.NoError(t, s.Flush())
+
+	assert.Equal(t, "event: greeting\ndata: hello\ndata: world\n\n"+
+		"data: ping\n\n"+
+		"event: item\ndata: {\"id\":1}\n\n"+
+		"data: a\ndata: b\n\n", w.String())
+	assert.Equal(t, 5, w.flushed)
+}
+
+func TestNDJSONWriter_Encode(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	bw := bufio.NewWriter(buf)
+	n := usecase.NewNDJSONWriter(bw)
+
+	assert.Equal(t, usecase.ContentTypeNDJSON, n.StreamContentType())
+
+	require.NoError(t, n.Encode(map[string]int{"id": 1}))
+	assert.Equal(t, "{\"id\":1}\n", buf.String(), "buffered writer must be flushed")
+
+	require.NoError(t, n.Encode("two"))
+	assert.Error(t, n.Encode(func() {}))
+	require.NoError(t, n.Flush())
+
+	assert.Equal(t, "{\"id\":1}\n\"two\"\n", buf.String())
+}
+
+func TestOutputWithEventStream(t *testing.T) {
+	type output struct {
+		usecase.OutputWithEventStream
+	}
+
+	u := usecase.NewIOI(nil, new(output), func(ctx context.Context, input, o interface{}) error {
+		return o.(*output).WriteEvent("tick", 1)
+	})
+
+	out := u.OutputPort()
+
+	w, ok := out.(usecase.OutputWithWriter)
+	require.True(t, ok)
+
+	ct, ok := out.(usecase.HasStreamContentType)
+	require.True(t, ok)
+	assert.Equal(t, "text/event-stream", ct.StreamContentType())
+
+	buf := bytes.NewBuffer(nil)
+	w.SetWriter(buf)
+
+	_, ok = out.(usecase.EventWriter)
+	assert.True(t, ok)
+
+	require.NoError(t, u.Interact(context.Background(), nil, out))
+	assert.Equal(t, "event: tick\ndata: 1\n\n", buf.String())
+}
+
+func TestOutputWithNDJSON(t *testing.T) {
+	type output struct {
+		usecase.OutputWithNDJSON
+	}
+
+	out := &output{}
+
+	assert.Equal(t, "application/x-ndjson", out.StreamContentType())
+
+	buf := bytes.NewBuffer(nil)
+	out.SetWriter(buf)
+
+	var enc usecase.JSONEncoder = out
+
+	require.NoError(t, enc.Encode(1))
+	require.NoError(t, enc.Encode(2))
+	assert.Equal(t, "1\n2\n", buf.String())
+}
@@ -0,0 +1,485 @@
+// Package usecaselint provides static analyzer of use case definitions.
+//
+// It is a separate module, so that golang.org/x/tools is not a dependency of github.com/swaggest/usecase.
+//
+// Analyzer can be run with a driver from golang.org/x/tools/go/analysis, e.g.
+//
+//	func main() {
+//		singlechecker.Main(usecaselint.Analyzer)
+//	}
+package usecaselint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	usecasePkg = "github.com/swaggest/usecase"
+	statusPkg  = "github.com/swaggest/usecase/status"
+)
+
+// Analyzer checks use case definitions for mistakes that otherwise only show up at runtime.
+//
+// It reports:
+//   - type assertions of input or output in interact function of NewIOI that do not match declared ports,
+//   - non-pointer output port of NewIOI and pointer output type of NewInteractor,
+//   - status codes returned by interact function, but not listed in SetExpectedErrors
+//     (application errors with AppCode are matched by AppCode, not by status code),
+//   - use cases created in anonymous functions or package variables without SetName,
+//     as their names are derived from caller function ambiguously.
+var Analyzer = &analysis.Analyzer{
+	Name:     "usecaselint",
+	Doc:      "check use case definitions",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// namedConstructors derive use case name from caller function.
+var namedConstructors = map[string]bool{
+	"NewIOI":        true,
+	"NewInteractor": true,
+	"NewPipe":       true,
+	"NewPipeOf":     true,
+	"NewParallel":   true,
+	"NewParallelOf": true,
+	"NewSaga":       true,
+	"NewRouter":     true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert // Guaranteed by Requires.
+	vars := packageVars(pass)
+
+	ins.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		call := n.(*ast.CallExpr) //nolint:forcetypeassert // Guaranteed by node filter.
+
+		name := usecaseFunc(pass, call.Fun)
+		if !namedConstructors[name] {
+			return true
+		}
+
+		checkName(pass, call, stack)
+
+		switch name {
+		case "NewIOI":
+			if len(call.Args) >= 3 {
+				checkIOI(pass, call)
+				checkExpectedErrors(pass, call.Args[2], stack, vars)
+			}
+		case "NewInteractor":
+			if len(call.Args) >= 1 {
+				checkInteractor(pass, call)
+				checkExpectedErrors(pass, call.Args[0], stack, vars)
+			}
+		}
+
+		return true
+	})
+
+	return nil, nil //nolint:nilnil // Analyzer has no result.
+}
+
+// usecaseFunc returns name of usecase package function that is called, or empty string.
+func usecaseFunc(pass *analysis.Pass, fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	var id *ast.Ident
+
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		id = f.Sel
+	case *ast.Ident:
+		id = f
+	default:
+		return ""
+	}
+
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != usecasePkg {
+		return ""
+	}
+
+	return fn.Name()
+}
+
+// checkIOI checks ports of NewIOI call against type assertions in interact function.
+func checkIOI(pass *analysis.Pass, call *ast.CallExpr) {
+	input := pass.TypesInfo.TypeOf(call.Args[0])
+	output := pass.TypesInfo.TypeOf(call.Args[1])
+
+	if !isNil(output) {
+		if _, ok := output.Underlying().(*types.Pointer); !ok {
+			pass.Reportf(call.Args[1].Pos(), "output port %s is not a pointer", typeString(pass, output))
+		}
+	}
+
+	fn, ok := call.Args[2].(*ast.FuncLit)
+	if !ok {
+		return
+	}
+
+	params := paramObjects(pass, fn)
+	if len(params) != 3 {
+		return
+	}
+
+	ports := map[types.Object]types.Type{
+		params[1]: input,
+		params[2]: output,
+	}
+	names := map[types.Object]string{
+		params[1]: "input",
+		params[2]: "output",
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		ta, ok := n.(*ast.TypeAssertExpr)
+		if !ok || ta.Type == nil {
+			return true
+		}
+
+		id, ok := ta.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		obj := pass.TypesInfo.Uses[id]
+
+		declared, found := ports[obj]
+		if !found || obj == nil || isNil(declared) {
+			return true
+		}
+
+		asserted := pass.TypesInfo.TypeOf(ta.Type)
+		if asserted != nil && !types.Identical(asserted, declared) {
+			pass.Reportf(ta.Pos(), "%s is asserted as %s, but %s port is %s",
+				names[obj], typeString(pass, asserted), names[obj], typeString(pass, declared))
+		}
+
+		return true
+	})
+}
+
+// checkInteractor checks that output type of NewInteractor is not a pointer.
+func checkInteractor(pass *analysis.Pass, call *ast.CallExpr) {
+	sig, ok := pass.TypesInfo.TypeOf(call.Args[0]).(*types.Signature)
+	if !ok || sig.Params().Len() != 3 {
+		return
+	}
+
+	out, ok := sig.Params().At(2).Type().(*types.Pointer)
+	if !ok {
+		return
+	}
+
+	if _, ok := out.Elem().Underlying().(*types.Pointer); ok {
+		pass.Reportf(call.Args[0].Pos(), "output type %s is a pointer, output port becomes a double pointer",
+			typeString(pass, out.Elem()))
+	}
+}
+
+// checkName reports use case created without SetName in a place where name can not be derived clearly.
+func checkName(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	var (
+		scope ast.Node
+		where string
+	)
+
+	for i := len(stack) - 1; i >= 0 && scope == nil; i-- {
+		switch s := stack[i].(type) {
+		case *ast.FuncLit:
+			scope, where = s, "anonymous function"
+		case *ast.FuncDecl:
+			return
+		case *ast.GenDecl:
+			// Package level declaration is a direct child of file.
+			if i == 1 {
+				scope, where = s, "package variable"
+			}
+		}
+	}
+
+	if scope == nil || callsMethod(scope, "SetName") {
+		return
+	}
+
+	pass.Reportf(call.Pos(), "use case is created in %s, its name is derived ambiguously, set it with SetName", where)
+}
+
+// checkExpectedErrors reports status codes that are returned by interact function,
+// but not listed with SetExpectedErrors in enclosing declaration.
+func checkExpectedErrors(pass *analysis.Pass, interact ast.Expr, stack []ast.Node, vars map[types.Object]ast.Expr) {
+	fn, ok := interact.(*ast.FuncLit)
+	if !ok {
+		return
+	}
+
+	// Outermost declaration is searched for SetExpectedErrors, including option functions.
+	var scope ast.Node
+
+	for _, s := range stack {
+		if _, ok := s.(*ast.FuncDecl); ok {
+			scope = s
+
+			break
+		}
+
+		if _, ok := s.(*ast.GenDecl); ok {
+			scope = s
+
+			break
+		}
+	}
+
+	if scope == nil {
+		return
+	}
+
+	expected, known := expectedCodes(pass, scope, vars)
+	if !known {
+		return
+	}
+
+	for _, c := range returnedCodes(pass, fn) {
+		if !expected[c.obj] {
+			pass.Reportf(c.pos, "status.%s is returned, but not listed in SetExpectedErrors", c.obj.Name())
+		}
+	}
+}
+
+type codeRef struct {
+	obj types.Object
+	pos token.Pos
+}
+
+// returnedCodes collects status codes used in return statements of function literal.
+func returnedCodes(pass *analysis.Pass, fn *ast.FuncLit) []codeRef {
+	var codes []codeRef
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, r := range s.Results {
+				ast.Inspect(r, func(n ast.Node) bool {
+					// Application error is expected by AppCode, its status code is not checked.
+					if lit, ok := n.(*ast.CompositeLit); ok && isAppError(pass, lit) {
+						return false
+					}
+
+					if obj := statusCode(pass, n); obj != nil {
+						codes = append(codes, codeRef{obj: obj, pos: n.Pos()})
+
+						return false
+					}
+
+					return true
+				})
+			}
+		}
+
+		return true
+	})
+
+	return codes
+}
+
+// expectedCodes collects status codes from arguments of SetExpectedErrors calls,
+// known is false if expected errors can not be resolved statically.
+func expectedCodes(pass *analysis.Pass, scope ast.Node, vars map[types.Object]ast.Expr) (codes map[types.Object]bool, known bool) {
+	codes = make(map[types.Object]bool)
+	known = true
+
+	ast.Inspect(scope, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "SetExpectedErrors" {
+			return true
+		}
+
+		if call.Ellipsis.IsValid() {
+			known = false
+		}
+
+		for _, arg := range call.Args {
+			if !collectCodes(pass, arg, vars, codes) {
+				known = false
+			}
+		}
+
+		return true
+	})
+
+	return codes, known
+}
+
+// collectCodes adds status codes referenced in expression and package variables it uses,
+// it returns false if expression has neither status codes nor application errors.
+//
+// Status codes of application errors (usecase.Error with AppCode) are not collected, because
+// usecase.IsExpected matches such errors by AppCode and does not fall back to status code.
+func collectCodes(pass *analysis.Pass, e ast.Expr, vars map[types.Object]ast.Expr, codes map[types.Object]bool) bool {
+	found := false
+
+	ast.Inspect(e, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.CompositeLit); ok && isAppError(pass, lit) {
+			found = true
+
+			return false
+		}
+
+		if obj := statusCode(pass, n); obj != nil {
+			codes[obj] = true
+			found = true
+
+			return false
+		}
+
+		if id, ok := n.(*ast.Ident); ok {
+			if init, ok := vars[pass.TypesInfo.Uses[id]]; ok && collectCodes(pass, init, nil, codes) {
+				found = true
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// isAppError checks if composite literal is usecase.Error with AppCode.
+func isAppError(pass *analysis.Pass, lit *ast.CompositeLit) bool {
+	named, ok := pass.TypesInfo.TypeOf(lit).(*types.Named)
+	if !ok || named.Obj().Name() != "Error" || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != usecasePkg {
+		return false
+	}
+
+	for _, el := range lit.Elts {
+		if kv, ok := el.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "AppCode" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// statusCode returns status code constant referenced by node, or nil.
+func statusCode(pass *analysis.Pass, n ast.Node) types.Object {
+	var id *ast.Ident
+
+	switch e := n.(type) {
+	case *ast.SelectorExpr:
+		id = e.Sel
+	case *ast.Ident:
+		id = e
+	default:
+		return nil
+	}
+
+	c, ok := pass.TypesInfo.Uses[id].(*types.Const)
+	if !ok || c.Pkg() == nil || c.Pkg().Path() != statusPkg {
+		return nil
+	}
+
+	if named, ok := c.Type().(*types.Named); !ok || named.Obj().Name() != "Code" {
+		return nil
+	}
+
+	return c
+}
+
+// packageVars maps package variables to their initialization expressions.
+func packageVars(pass *analysis.Pass) map[types.Object]ast.Expr {
+	vars := make(map[types.Object]ast.Expr)
+
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec) //nolint:forcetypeassert // Var declaration has value specs.
+				if len(vs.Names) != len(vs.Values) {
+					continue
+				}
+
+				for i, name := range vs.Names {
+					if obj := pass.TypesInfo.Defs[name]; obj != nil {
+						vars[obj] = vs.Values[i]
+					}
+				}
+			}
+		}
+	}
+
+	return vars
+}
+
+// callsMethod checks if node has a call of method with name.
+func callsMethod(node ast.Node, name string) bool {
+	found := false
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == name {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func paramObjects(pass *analysis.Pass, fn *ast.FuncLit) []types.Object {
+	var params []types.Object
+
+	for _, f := range fn.Type.Params.List {
+		if len(f.Names) == 0 {
+			params = append(params, nil)
+
+			continue
+		}
+
+		for _, name := range f.Names {
+			params = append(params, pass.TypesInfo.Defs[name])
+		}
+	}
+
+	return params
+}
+
+func isNil(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+
+	return t == nil || ok && b.Kind() == types.UntypedNil
+}
+
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(pass.Pkg))
+}
@@ -0,0 +1,12 @@
+package usecaselint_test
+
+import (
+	"testing"
+
+	"github.com/swaggest/usecase/usecaselint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), usecaselint.Analyzer, "a")
+}
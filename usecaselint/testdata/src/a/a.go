@@ -0,0 +1,127 @@
+package a
+
+import (
+	"context"
+	"errors"
+
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type orderInput struct {
+	ID int
+}
+
+type orderOutput struct {
+	Status string
+}
+
+var errLocked = usecase.Error{AppCode: 1, StatusCode: status.FailedPrecondition}
+
+func valid() usecase.IOInteractor {
+	u := usecase.NewIOI(new(orderInput), new(orderOutput), func(ctx context.Context, input, output interface{}) error {
+		in := input.(*orderInput)
+		out := output.(*orderOutput)
+
+		switch in.ID {
+		case 0:
+			return status.Wrap(errors.New("missing id"), status.InvalidArgument)
+		case 1:
+			return errLocked
+		case 2:
+			return usecase.Error{StatusCode: status.FailedPrecondition} // want `status.FailedPrecondition is returned, but not listed in SetExpectedErrors`
+		case 3:
+			return &usecase.Error{AppCode: 1, StatusCode: status.FailedPrecondition}
+		}
+
+		out.Status = "ok"
+
+		return nil
+	})
+
+	u.SetExpectedErrors(status.InvalidArgument, errLocked)
+
+	return u
+}
+
+func typeDrift() usecase.IOInteractor {
+	return usecase.NewIOI(orderInput{}, new(orderOutput), func(ctx context.Context, input, output interface{}) error {
+		_ = input.(*orderInput)     // want `input is asserted as \*orderInput, but input port is orderInput`
+		_ = output.(orderOutput)    // want `output is asserted as orderOutput, but output port is \*orderOutput`
+		_, _ = output.(*orderInput) // want `output is asserted as \*orderInput, but output port is \*orderOutput`
+
+		return nil
+	})
+}
+
+func nonPointerOutput() usecase.IOInteractor {
+	return usecase.NewIOI(nil, orderOutput{}, nil) // want `output port orderOutput is not a pointer`
+}
+
+func doublePointer() usecase.IOInteractorOf[orderInput, *orderOutput] {
+	return usecase.NewInteractor(func(ctx context.Context, input orderInput, output **orderOutput) error { // want `output type \*orderOutput is a pointer, output port becomes a double pointer`
+		return nil
+	})
+}
+
+func unlisted() usecase.IOInteractor {
+	u := usecase.NewIOI(nil, nil, func(ctx context.Context, input, output interface{}) error {
+		if input == nil {
+			return status.NotFound // want `status.NotFound is returned, but not listed in SetExpectedErrors`
+		}
+
+		return status.Wrap(errors.New("bad"), status.InvalidArgument)
+	})
+
+	u.SetExpectedErrors(status.InvalidArgument)
+
+	return u
+}
+
+func unlistedGeneric() usecase.IOInteractorOf[int, int] {
+	return usecase.NewInteractor(func(ctx context.Context, input int, output *int) error {
+		return status.NotFound // want `status.NotFound is returned, but not listed in SetExpectedErrors`
+	}, func(i *usecase.IOInteractor) {
+		i.SetExpectedErrors(status.InvalidArgument)
+	})
+}
+
+func unresolved(expected []error) usecase.IOInteractor {
+	u := usecase.NewIOI(nil, nil, func(ctx context.Context, input, output interface{}) error {
+		return status.NotFound
+	})
+
+	u.SetExpectedErrors(expected...)
+
+	return u
+}
+
+func anonymous() []usecase.IOInteractor {
+	var res []usecase.IOInteractor
+
+	for _, name := range []string{"a", "b"} {
+		func() {
+			res = append(res, usecase.NewPipe(nil)) // want `use case is created in anonymous function, its name is derived ambiguously, set it with SetName`
+		}()
+
+		func() {
+			u := usecase.NewIOI(nil, nil, nil)
+			u.SetName(name)
+			res = append(res, u)
+		}()
+	}
+
+	return res
+}
+
+var global = usecase.NewIOI(nil, nil, nil) // want `use case is created in package variable, its name is derived ambiguously, set it with SetName`
+
+var named = usecase.NewIOI(nil, nil, nil, func(i *usecase.IOInteractor) {
+	i.SetName("named")
+})
+
+func local() usecase.IOInteractor {
+	var u = usecase.NewIOI(nil, nil, nil)
+
+	return u
+}
@@ -0,0 +1,17 @@
+// Package status is a stub of github.com/swaggest/usecase/status.
+package status
+
+type Code int
+
+const (
+	OK Code = iota
+	Canceled
+	Unknown
+	InvalidArgument
+	NotFound
+	FailedPrecondition
+)
+
+func (c Code) Error() string { return "" }
+
+func Wrap(err error, code Code) error { return err }
@@ -0,0 +1,54 @@
+// Package usecase is a stub of github.com/swaggest/usecase.
+package usecase
+
+import (
+	"context"
+
+	"github.com/swaggest/usecase/status"
+)
+
+type Interactor interface {
+	Interact(ctx context.Context, input, output interface{}) error
+}
+
+type Interact func(ctx context.Context, input, output interface{}) error
+
+func (i Interact) Interact(ctx context.Context, input, output interface{}) error {
+	return i(ctx, input, output)
+}
+
+type IOInteractor struct {
+	Interactor
+}
+
+func (i *IOInteractor) SetName(name string) {}
+
+func (i *IOInteractor) SetExpectedErrors(errors ...error) {}
+
+type IOInteractorOf[i, o any] struct {
+	IOInteractor
+}
+
+type Error struct {
+	AppCode    int
+	StatusCode status.Code
+	Value      error
+}
+
+func (e Error) Error() string { return "" }
+
+type PipeStep struct {
+	Interactor
+}
+
+func NewIOI(input, output interface{}, interact Interact, options ...func(i *IOInteractor)) IOInteractor {
+	return IOInteractor{}
+}
+
+func NewInteractor[i, o any](interact func(ctx context.Context, input i, output *o) error, options ...func(i *IOInteractor)) IOInteractorOf[i, o] {
+	return IOInteractorOf[i, o]{}
+}
+
+func NewPipe(steps []PipeStep, options ...func(i *IOInteractor)) IOInteractor {
+	return IOInteractor{}
+}
@@ -0,0 +1,2 @@
+// Package usecasetest provides helpers to test use case interactors.
+package usecasetest
@@ -0,0 +1,303 @@
+package usecasetest
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/swaggest/usecase"
+)
+
+const (
+	fuzzMaxDepth     = 5
+	fuzzMaxLength    = 32
+	fuzzMaxItems     = 8
+	fuzzUnboundRange = 1 << 20
+)
+
+// Invariant checks result of fuzzed use case interaction.
+type Invariant func(t testing.TB, input, output interface{}, err error)
+
+func fuzzCase(t testing.TB, u usecase.Interactor, data []byte, invariants []Invariant) {
+	t.Helper()
+
+	input := fuzzInput(u, data)
+	output := usecase.NewOutput(u)
+
+	err, panicked := safeInteract(u, input, output)
+	if panicked != nil {
+		t.Errorf("panic for input %+v: %s", deref(input), panicked.Error())
+
+		return
+	}
+
+	if err != nil {
+		if usecase.StatusOf(err) == 0 {
+			t.Errorf("error %q for input %+v has no status code", err.Error(), deref(input))
+		}
+
+		checkExpectedErrors(t, u, err)
+	}
+
+	for _, check := range invariants {
+		check(t, input, output, err)
+	}
+}
+
+// safeInteract invokes interactor and recovers panic.
+func safeInteract(u usecase.Interactor, input, output interface{}) (err error, panicked error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = fmt.Errorf("%v\n%s", r, debug.Stack()) //nolint:goerr113 // Panic is described for a report.
+		}
+	}()
+
+	return u.Interact(context.Background(), input, output), nil
+}
+
+// fuzzInput creates value of input port from fuzzing data.
+func fuzzInput(u usecase.Interactor, data []byte) interface{} {
+	var withInput usecase.HasInputPort
+
+	if !usecase.As(u, &withInput) || withInput.InputPort() == nil {
+		return nil
+	}
+
+	p := usecase.PortInfoOf(withInput.InputPort())
+	v := reflect.New(p.Type)
+	s := fuzzSource{data: data}
+	s.fill(v.Elem(), "", 0)
+
+	if p.IsPtr {
+		return v.Interface()
+	}
+
+	return v.Elem().Interface()
+}
+
+// fuzzSource consumes fuzzing data to populate values, zeros are used when data is exhausted.
+type fuzzSource struct {
+	data []byte
+}
+
+func (s *fuzzSource) uint64() uint64 {
+	var b [8]byte
+
+	n := copy(b[:], s.data)
+	s.data = s.data[n:]
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (s *fuzzSource) byte() byte {
+	if len(s.data) == 0 {
+		return 0
+	}
+
+	b := s.data[0]
+	s.data = s.data[1:]
+
+	return b
+}
+
+// intn returns value in [0, n).
+func (s *fuzzSource) intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+
+	return int(s.uint64() % uint64(n))
+}
+
+// length returns value in [min, max] with bounds from tags.
+func (s *fuzzSource) length(tag reflect.StructTag, minKey, maxKey string, defaultMax int) int {
+	lo, hi := 0, defaultMax
+
+	if v, err := strconv.Atoi(tag.Get(minKey)); err == nil {
+		lo = v
+	}
+
+	if v, err := strconv.Atoi(tag.Get(maxKey)); err == nil {
+		hi = v
+	}
+
+	if hi < lo {
+		hi = lo + defaultMax
+	}
+
+	return lo + s.intn(hi-lo+1)
+}
+
+func (s *fuzzSource) fill(v reflect.Value, tag reflect.StructTag, depth int) {
+	if depth > fuzzMaxDepth {
+		return
+	}
+
+	if enum := enumValues(tag); len(enum) > 0 && setString(v, enum[s.intn(len(enum))]) {
+		return
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds are left with zero values.
+	case reflect.Ptr:
+		if tag.Get("required") == "true" || s.byte()%2 == 1 {
+			v.Set(reflect.New(v.Type().Elem()))
+			s.fill(v.Elem(), tag, depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				s.fill(f, v.Type().Field(i).Tag, depth+1)
+			}
+		}
+	case reflect.Bool:
+		v.SetBool(s.byte()%2 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(s.number(v.Type(), tag)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(s.number(v.Type(), tag)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(s.number(v.Type(), tag))
+	case reflect.String:
+		r := make([]rune, s.length(tag, "minLength", "maxLength", fuzzMaxLength))
+		for i := range r {
+			r[i] = rune(s.byte())
+		}
+
+		v.SetString(string(r))
+	case reflect.Slice:
+		n := s.length(tag, "minItems", "maxItems", fuzzMaxItems)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+
+		for i := 0; i < n; i++ {
+			s.fill(v.Index(i), "", depth+1)
+		}
+	case reflect.Map:
+		n := s.length(tag, "minProperties", "maxProperties", fuzzMaxItems)
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			val := reflect.New(v.Type().Elem()).Elem()
+
+			s.fill(key, "", depth+1)
+			s.fill(val, "", depth+1)
+			v.SetMapIndex(key, val)
+		}
+	}
+}
+
+// number returns value within range of type and minimum and maximum from tags.
+func (s *fuzzSource) number(t reflect.Type, tag reflect.StructTag) float64 {
+	lo, hi := -math.MaxFloat64, math.MaxFloat64
+	integer := true
+
+	switch t.Kind() { //nolint:exhaustive // Only numeric kinds are expected.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi = -math.Exp2(float64(t.Bits()-1)), math.Exp2(float64(t.Bits()-1))-1
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi = 0, math.Exp2(float64(t.Bits()))-1
+	default:
+		integer = false
+	}
+
+	minimum, errMin := strconv.ParseFloat(tag.Get("minimum"), 64)
+	if errMin == nil && minimum > lo {
+		lo = minimum
+	}
+
+	maximum, errMax := strconv.ParseFloat(tag.Get("maximum"), 64)
+	if errMax == nil && maximum < hi {
+		hi = maximum
+	}
+
+	// Unbounded values are generated close to the bound from tag, or to zero.
+	if hi-lo > fuzzUnboundRange {
+		switch {
+		case errMin == nil:
+			hi = lo + fuzzUnboundRange
+		case errMax == nil:
+			lo = hi - fuzzUnboundRange
+		default:
+			lo, hi = math.Max(lo, -fuzzUnboundRange/2), math.Min(hi, fuzzUnboundRange/2)
+		}
+	}
+
+	r := s.uint64()
+
+	if hi <= lo {
+		return lo
+	}
+
+	if integer {
+		return math.Ceil(lo) + float64(r%uint64(math.Floor(hi)-math.Ceil(lo)+1))
+	}
+
+	return lo + (hi-lo)*(float64(r)/float64(math.MaxUint64))
+}
+
+// enumValues parses enum tag as JSON array or comma-separated list.
+func enumValues(tag reflect.StructTag) []string {
+	enum := tag.Get("enum")
+	if enum == "" {
+		return nil
+	}
+
+	var items []interface{}
+	if err := json.Unmarshal([]byte(enum), &items); err == nil {
+		res := make([]string, 0, len(items))
+		for _, item := range items {
+			res = append(res, fmt.Sprint(item))
+		}
+
+		return res
+	}
+
+	return strings.Split(enum, ",")
+}
+
+// setString sets value parsed from string and returns false if value kind is not supported.
+func setString(v reflect.Value, s string) bool {
+	switch v.Kind() { //nolint:exhaustive // Other kinds are not supported.
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return false
+		}
+
+		v.SetFloat(f)
+	case reflect.Ptr:
+		e := reflect.New(v.Type().Elem())
+		if !setString(e.Elem(), s) {
+			return false
+		}
+
+		v.Set(e)
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,31 @@
+//go:build go1.18
+// +build go1.18
+
+package usecasetest
+
+import (
+	"testing"
+
+	"github.com/swaggest/usecase"
+)
+
+// Fuzz runs native fuzz test of use case interactor.
+//
+// Values of input port are generated from fuzzing data, these field tags are respected:
+// minimum, maximum, minLength, maxLength, minItems, maxItems, enum and required.
+//
+// Every interaction is checked to not panic, and to return errors that have status code
+// and are listed in expected errors of interactor. Additional invariants are checked after that.
+func Fuzz(f *testing.F, u usecase.Interactor, invariants ...Invariant) {
+	f.Helper()
+
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte("usecase"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		t.Helper()
+
+		fuzzCase(t, u, data, invariants)
+	})
+}
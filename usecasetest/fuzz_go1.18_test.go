@@ -0,0 +1,23 @@
+//go:build go1.18
+// +build go1.18
+
+package usecasetest_test
+
+import (
+	"testing"
+
+	"github.com/swaggest/usecase/usecasetest"
+)
+
+func FuzzOrder(f *testing.F) {
+	usecasetest.Fuzz(f, newOrderUseCase(), func(t testing.TB, input, output interface{}, err error) {
+		t.Helper()
+
+		in := input.(*orderInput)
+		out := output.(*orderOutput)
+
+		if err == nil && out.ID != in.ID {
+			t.Errorf("unexpected output ID %d for input ID %d", out.ID, in.ID)
+		}
+	})
+}
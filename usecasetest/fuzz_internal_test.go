@@ -0,0 +1,131 @@
+package usecasetest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+type fuzzItem struct {
+	Name string `json:"name"`
+}
+
+type fuzzOrder struct {
+	ID       int               `json:"id" minimum:"1" maximum:"100"`
+	Price    float64           `json:"price" minimum:"0.5" maximum:"2"`
+	Quantity uint8             `json:"quantity" minimum:"3"`
+	Code     string            `json:"code" minLength:"2" maxLength:"4"`
+	Kind     string            `json:"kind" enum:"new,used"`
+	Priority int               `json:"priority" enum:"[1,5]"`
+	Note     *string           `json:"note" required:"true" maxLength:"3"`
+	Items    []fuzzItem        `json:"items" minItems:"1" maxItems:"2"`
+	Labels   map[string]string `json:"labels"`
+	secret   string
+}
+
+func TestFuzzInput(t *testing.T) {
+	u := usecase.NewIOI(new(fuzzOrder), nil, nil)
+
+	for _, data := range [][]byte{
+		nil,
+		[]byte("usecase"),
+		[]byte(strings.Repeat("\xff", 100)),
+		[]byte(strings.Repeat("abcdefgh\x00\x01", 20)),
+	} {
+		o, ok := fuzzInput(u, data).(*fuzzOrder)
+		require.True(t, ok)
+
+		assert.GreaterOrEqual(t, o.ID, 1)
+		assert.LessOrEqual(t, o.ID, 100)
+		assert.GreaterOrEqual(t, o.Price, 0.5)
+		assert.LessOrEqual(t, o.Price, 2.0)
+		assert.GreaterOrEqual(t, o.Quantity, uint8(3))
+		assert.GreaterOrEqual(t, len([]rune(o.Code)), 2)
+		assert.LessOrEqual(t, len([]rune(o.Code)), 4)
+		assert.Contains(t, []string{"new", "used"}, o.Kind)
+		assert.Contains(t, []int{1, 5}, o.Priority)
+		require.NotNil(t, o.Note)
+		assert.LessOrEqual(t, len([]rune(*o.Note)), 3)
+		assert.NotEmpty(t, o.Items)
+		assert.LessOrEqual(t, len(o.Items), 2)
+		assert.Empty(t, o.secret)
+	}
+
+	v := usecase.NewIOI(fuzzOrder{}, nil, nil)
+	assert.IsType(t, fuzzOrder{}, fuzzInput(v, nil))
+
+	assert.Nil(t, fuzzInput(usecase.NewIOI(nil, nil, nil), nil))
+}
+
+type fuzzCode struct {
+	Code int `minimum:"0" maximum:"5"`
+}
+
+func TestFuzzCase_failures(t *testing.T) {
+	u := usecase.NewIOI(new(fuzzCode), new(int), func(ctx context.Context, input, output interface{}) error {
+		switch input.(*fuzzCode).Code {
+		case 1:
+			return status.NotFound
+		case 2:
+			return status.Internal
+		case 3:
+			return errors.New("failed")
+		case 4:
+			panic("boom")
+		case 5:
+			return usecase.Error{AppCode: 3, Value: status.NotFound}
+		}
+
+		*output.(*int) = 1
+
+		return nil
+	})
+	u.SetExpectedErrors(status.NotFound)
+
+	outputIsSet := func(t testing.TB, input, output interface{}, err error) {
+		t.Helper()
+
+		if err == nil && *output.(*int) != 1 {
+			t.Errorf("output is not set")
+		}
+	}
+
+	for _, tc := range []struct {
+		data     []byte
+		failures []string
+	}{
+		{data: []byte{0}},
+		{data: []byte{1}},
+		{data: []byte{2}, failures: []string{`error "internal" is not listed in ExpectedErrors()`}},
+		{data: []byte{3}, failures: []string{
+			`error "failed" for input {Code:3} has no status code`,
+			`error "failed" is not listed in ExpectedErrors()`,
+		}},
+		{data: []byte{4}, failures: []string{"panic for input {Code:4}: boom"}},
+		{data: []byte{5}},
+	} {
+		r := &recorder{}
+		fuzzCase(r, u, tc.data, []Invariant{outputIsSet})
+
+		require.Len(t, r.failures, len(tc.failures), "%v", r.failures)
+
+		for i, f := range tc.failures {
+			assert.Contains(t, r.failures[i], f)
+		}
+	}
+
+	failing := func(t testing.TB, input, output interface{}, err error) {
+		t.Helper()
+		t.Errorf("invariant failed for %v", deref(input))
+	}
+
+	r := &recorder{}
+	fuzzCase(r, u, []byte{0}, []Invariant{failing})
+	assert.Equal(t, []string{"invariant failed for {0}"}, r.failures)
+}
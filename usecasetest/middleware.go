@@ -0,0 +1,222 @@
+package usecasetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+// MiddlewareCheck configures CheckMiddleware.
+type MiddlewareCheck struct {
+	// ChangesPorts allows middleware to expose ports different from wrapped interactor.
+	ChangesPorts bool
+
+	// ChangesError allows middleware to return error different from error of wrapped interactor.
+	ChangesError bool
+}
+
+// CheckMiddleware checks that middleware conforms to contract of usecase.Middleware
+// and returns true if it does.
+//
+// Middleware wraps a probe interactor, and it is checked that:
+//   - context values, input and output are passed to probe,
+//   - probe is invoked exactly once per interaction,
+//   - ports of probe are kept,
+//   - errors of probe are returned unchanged,
+//   - name, ports, expected errors and custom behaviors of probe are available with usecase.As
+//     and are not shadowed by middleware.
+func CheckMiddleware(t testing.TB, mw usecase.Middleware, options ...func(c *MiddlewareCheck)) bool {
+	t.Helper()
+
+	c := MiddlewareCheck{}
+
+	for _, o := range options {
+		o(&c)
+	}
+
+	p := newProbe()
+	u := usecase.Wrap(p, mw)
+
+	ok := checkAs(t, u, p)
+
+	if !c.ChangesPorts {
+		ok = checkPorts(t, u, p) && ok
+	}
+
+	ok = checkProbeCall(t, u, p, nil, c) && ok
+	ok = checkProbeCall(t, u, p, errProbe, c) && ok
+
+	return ok
+}
+
+type probeCtxKey struct{}
+
+var errProbe = &usecase.Error{AppCode: 42, StatusCode: status.Aborted, Value: errors.New("probe failed")}
+
+type probeInput struct {
+	Value string `json:"value"`
+}
+
+type probeOutput struct {
+	Value string `json:"value"`
+}
+
+// probeBehavior is a custom behavior of probe that is looked up with usecase.As.
+type probeBehavior interface {
+	probe() *probe
+}
+
+// probe is a use case interactor that records its invocations.
+type probe struct {
+	usecase.IOInteractor
+
+	calls int
+	ctx   context.Context //nolint:containedctx // Context of last call is recorded.
+	input interface{}
+	out   interface{}
+	err   error
+}
+
+func newProbe() *probe {
+	p := &probe{}
+	p.Input = new(probeInput)
+	p.Output = new(probeOutput)
+	p.SetName("usecasetest.probe")
+	p.SetTitle("Probe")
+	p.SetExpectedErrors(errProbe)
+	p.Interactor = usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		p.calls++
+		p.ctx = ctx
+		p.input = input
+		p.out = output
+
+		return p.err
+	})
+
+	return p
+}
+
+func (p *probe) probe() *probe {
+	return p
+}
+
+func checkAs(t testing.TB, u usecase.Interactor, p *probe) bool {
+	t.Helper()
+
+	var (
+		withInput          usecase.HasInputPort
+		withOutput         usecase.HasOutputPort
+		withName           usecase.HasName
+		withExpectedErrors usecase.HasExpectedErrors
+		withBehavior       probeBehavior
+	)
+
+	ok := true
+
+	for _, target := range []interface{}{&withInput, &withOutput, &withName, &withExpectedErrors, &withBehavior} {
+		if !usecase.As(u, target) {
+			t.Errorf("%s is not available with usecase.As after wrapping", reflect.TypeOf(target).Elem())
+
+			ok = false
+		}
+	}
+
+	if withName != nil && withName.Name() != p.Name() {
+		t.Errorf("name changed: %q, expected: %q", withName.Name(), p.Name())
+
+		ok = false
+	}
+
+	if withExpectedErrors != nil && !usecase.IsExpected(errProbe, withExpectedErrors.ExpectedErrors()...) {
+		t.Errorf("expected errors of wrapped interactor are lost: %v", withExpectedErrors.ExpectedErrors())
+
+		ok = false
+	}
+
+	if withBehavior != nil && withBehavior.probe() != p {
+		t.Errorf("custom behavior of wrapped interactor is replaced")
+
+		ok = false
+	}
+
+	return ok
+}
+
+func checkPorts(t testing.TB, u usecase.Interactor, p *probe) bool {
+	t.Helper()
+
+	var (
+		withInput  usecase.HasInputPort
+		withOutput usecase.HasOutputPort
+	)
+
+	ok := true
+
+	if usecase.As(u, &withInput) && reflect.TypeOf(withInput.InputPort()) != reflect.TypeOf(p.Input) {
+		t.Errorf("input port changed: %T, expected: %T", withInput.InputPort(), p.Input)
+
+		ok = false
+	}
+
+	if usecase.As(u, &withOutput) && reflect.TypeOf(withOutput.OutputPort()) != reflect.TypeOf(p.Output) {
+		t.Errorf("output port changed: %T, expected: %T", withOutput.OutputPort(), p.Output)
+
+		ok = false
+	}
+
+	return ok
+}
+
+func checkProbeCall(t testing.TB, u usecase.Interactor, p *probe, probeErr error, c MiddlewareCheck) bool {
+	t.Helper()
+
+	p.calls = 0
+	p.ctx = nil
+	p.input = nil
+	p.out = nil
+	p.err = probeErr
+
+	ctx := context.WithValue(context.Background(), probeCtxKey{}, "probe")
+	input := &probeInput{Value: "input"}
+	output := new(probeOutput)
+
+	err := u.Interact(ctx, input, output)
+
+	if p.calls != 1 {
+		t.Errorf("wrapped interactor is called %d times, expected once", p.calls)
+
+		return false
+	}
+
+	ok := true
+
+	if p.ctx.Value(probeCtxKey{}) != "probe" {
+		t.Errorf("context values are not passed to wrapped interactor")
+
+		ok = false
+	}
+
+	if p.input != input {
+		t.Errorf("input is not passed to wrapped interactor: %+v", p.input)
+
+		ok = false
+	}
+
+	if p.out != output {
+		t.Errorf("output is not passed to wrapped interactor: %+v", p.out)
+
+		ok = false
+	}
+
+	if !c.ChangesError && err != probeErr { //nolint:errorlint,goerr113 // Exact match is needed.
+		t.Errorf("error is not passed through, received: %v, expected: %v", err, probeErr)
+
+		ok = false
+	}
+
+	return ok
+}
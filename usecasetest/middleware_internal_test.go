@@ -0,0 +1,99 @@
+package usecasetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+)
+
+func TestCheckMiddleware_failures(t *testing.T) {
+	interact := func(f func(next usecase.Interactor, ctx context.Context, input, output interface{}) error) usecase.Middleware {
+		return usecase.MiddlewareFunc(func(next usecase.Interactor) usecase.Interactor {
+			return usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+				return f(next, ctx, input, output)
+			})
+		})
+	}
+
+	for _, tc := range []struct {
+		name     string
+		mw       usecase.Middleware
+		failures []string
+	}{
+		{
+			name: "calls next twice",
+			mw: interact(func(next usecase.Interactor, ctx context.Context, input, output interface{}) error {
+				_ = next.Interact(ctx, input, output) //nolint:errcheck // Test case.
+
+				return next.Interact(ctx, input, output)
+			}),
+			failures: []string{
+				"wrapped interactor is called 2 times, expected once",
+				"wrapped interactor is called 2 times, expected once",
+			},
+		},
+		{
+			name: "skips next",
+			mw: interact(func(next usecase.Interactor, ctx context.Context, input, output interface{}) error {
+				return nil
+			}),
+			failures: []string{
+				"wrapped interactor is called 0 times, expected once",
+				"wrapped interactor is called 0 times, expected once",
+			},
+		},
+		{
+			name: "drops context and ports",
+			mw: interact(func(next usecase.Interactor, ctx context.Context, input, output interface{}) error {
+				return next.Interact(context.Background(), new(probeInput), new(probeOutput))
+			}),
+			failures: []string{
+				"context values are not passed to wrapped interactor",
+				"input is not passed to wrapped interactor: &{Value:}",
+				"output is not passed to wrapped interactor: &{Value:}",
+				"context values are not passed to wrapped interactor",
+				"input is not passed to wrapped interactor: &{Value:}",
+				"output is not passed to wrapped interactor: &{Value:}",
+			},
+		},
+		{
+			name: "wraps error",
+			mw: interact(func(next usecase.Interactor, ctx context.Context, input, output interface{}) error {
+				if err := next.Interact(ctx, input, output); err != nil {
+					return fmt.Errorf("failed: %w", err)
+				}
+
+				return nil
+			}),
+			failures: []string{
+				"error is not passed through, received: failed: aborted: probe failed, expected: aborted: probe failed",
+			},
+		},
+		{
+			name: "shadows info",
+			mw: usecase.MiddlewareFunc(func(next usecase.Interactor) usecase.Interactor {
+				u := usecase.IOInteractor{}
+				u.Input = new(string)
+				u.Output = new(probeOutput)
+				u.Interactor = next
+
+				return u
+			}),
+			failures: []string{
+				`name changed: "", expected: "usecasetest.probe"`,
+				"expected errors of wrapped interactor are lost: []",
+				"input port changed: *string, expected: *usecasetest.probeInput",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &recorder{}
+
+			assert.Equal(t, len(tc.failures) == 0, CheckMiddleware(r, tc.mw))
+			assert.Equal(t, tc.failures, r.failures)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package usecasetest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+	"github.com/swaggest/usecase/usecasetest"
+)
+
+func TestCheckMiddleware(t *testing.T) {
+	assert.True(t, usecasetest.CheckMiddleware(t, usecase.ErrorCatcher(
+		func(ctx context.Context, input interface{}, err error) {},
+	)))
+
+	assert.True(t, usecasetest.CheckMiddleware(t, usecase.MiddlewareFunc(func(next usecase.Interactor) usecase.Interactor {
+		return usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+			return next.Interact(ctx, input, output)
+		})
+	})))
+}
+
+func TestCheckMiddleware_options(t *testing.T) {
+	mw := usecase.MiddlewareFunc(func(next usecase.Interactor) usecase.Interactor {
+		return struct {
+			usecase.Interactor
+			usecase.HasInputPort
+		}{
+			Interactor: usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+				if err := next.Interact(ctx, input, output); err != nil {
+					return status.Wrap(err, status.Internal)
+				}
+
+				return nil
+			}),
+			HasInputPort: usecase.WithInput{Input: new(string)},
+		}
+	})
+
+	assert.True(t, usecasetest.CheckMiddleware(t, mw, func(c *usecasetest.MiddlewareCheck) {
+		c.ChangesPorts = true
+		c.ChangesError = true
+	}))
+}
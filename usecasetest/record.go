@@ -0,0 +1,245 @@
+package usecasetest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+// Record is a recorded use case interaction.
+type Record struct {
+	Name     string          `json:"name,omitempty"`
+	Input    json.RawMessage `json:"input,omitempty"`
+	Output   json.RawMessage `json:"output,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Status   string          `json:"status,omitempty"`
+	AppCode  int             `json:"appCode,omitempty"`
+	Duration time.Duration   `json:"duration"`
+}
+
+// Recorder is a use case middleware that writes interactions as JSON lines.
+//
+// Records can be used with Replay for regression testing.
+type Recorder struct {
+	// OnError is called when interaction can not be recorded, optional.
+	OnError func(ctx context.Context, err error)
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+var _ usecase.Middleware = &Recorder{}
+
+// NewRecorder creates recording middleware.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Wrap implements usecase.Middleware.
+func (r *Recorder) Wrap(u usecase.Interactor) usecase.Interactor {
+	var (
+		withName usecase.HasName
+		name     string
+	)
+
+	if usecase.As(u, &withName) {
+		name = withName.Name()
+	}
+
+	return usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		rec := Record{Name: name}
+
+		in, err := json.Marshal(input)
+		if err != nil {
+			r.fail(ctx, fmt.Errorf("encoding input: %w", err))
+		} else {
+			rec.Input = in
+		}
+
+		start := time.Now()
+		ierr := u.Interact(ctx, input, output)
+		rec.Duration = time.Since(start)
+
+		if ierr != nil {
+			rec.Error = ierr.Error()
+			rec.Status, rec.AppCode = errorCodes(ierr)
+		} else if output != nil {
+			out, err := json.Marshal(output)
+			if err != nil {
+				r.fail(ctx, fmt.Errorf("encoding output: %w", err))
+			} else {
+				rec.Output = out
+			}
+		}
+
+		r.mu.Lock()
+		err = r.enc.Encode(rec)
+		r.mu.Unlock()
+
+		if err != nil {
+			r.fail(ctx, fmt.Errorf("writing record: %w", err))
+		}
+
+		return ierr
+	})
+}
+
+func (r *Recorder) fail(ctx context.Context, err error) {
+	if r.OnError != nil {
+		r.OnError(ctx, err)
+	}
+}
+
+// errorCodes returns status and application codes of error.
+func errorCodes(err error) (string, int) {
+	code := usecase.StatusOf(err)
+	if code == 0 {
+		code = status.Unknown
+	}
+
+	appCode := 0
+
+	var ae interface {
+		AppErrCode() int
+	}
+
+	if errors.As(err, &ae) {
+		appCode = ae.AppErrCode()
+	}
+
+	return code.String(), appCode
+}
+
+// Replay feeds recorded inputs to use case interactor and reports differences in outputs and error codes.
+//
+// Records of other use cases are skipped if interactor has a name.
+func Replay(t *testing.T, u usecase.Interactor, r io.Reader) {
+	t.Helper()
+
+	var (
+		withName usecase.HasName
+		name     string
+	)
+
+	if usecase.As(u, &withName) {
+		name = withName.Name()
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 16*1024*1024)
+
+	line := 0
+
+	for s.Scan() {
+		line++
+
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+
+		var rec Record
+
+		if err := json.Unmarshal(s.Bytes(), &rec); err != nil {
+			t.Errorf("decoding record at line %d: %v", line, err)
+
+			continue
+		}
+
+		if name != "" && rec.Name != "" && rec.Name != name {
+			continue
+		}
+
+		t.Run(fmt.Sprintf("line%d", line), func(t *testing.T) {
+			t.Helper()
+
+			replayRecord(t, u, rec)
+		})
+	}
+
+	if err := s.Err(); err != nil {
+		t.Errorf("reading records: %v", err)
+	}
+}
+
+func replayRecord(t testing.TB, u usecase.Interactor, rec Record) {
+	t.Helper()
+
+	input, err := inputFromJSON(u, rec.Input)
+	if err != nil {
+		t.Errorf("decoding input: %v", err)
+
+		return
+	}
+
+	output := usecase.NewOutput(u)
+
+	err = u.Interact(context.Background(), input, output)
+	if err != nil {
+		st, appCode := errorCodes(err)
+
+		if rec.Status == "" {
+			t.Errorf("unexpected error: %v", err)
+
+			return
+		}
+
+		assert.Equal(t, rec.Status, st, "status code differs for error %q, recorded: %q", err.Error(), rec.Error)
+		assert.Equal(t, rec.AppCode, appCode, "app code differs for error %q, recorded: %q", err.Error(), rec.Error)
+
+		return
+	}
+
+	if rec.Status != "" {
+		t.Errorf("error with status %s expected, recorded: %q", rec.Status, rec.Error)
+
+		return
+	}
+
+	if len(rec.Output) == 0 {
+		return
+	}
+
+	actual, err := json.Marshal(output)
+	if err != nil {
+		t.Errorf("encoding output: %v", err)
+
+		return
+	}
+
+	assert.JSONEq(t, string(rec.Output), string(actual), "output differs")
+}
+
+// inputFromJSON creates fresh value of input port from JSON.
+func inputFromJSON(u usecase.Interactor, data json.RawMessage) (interface{}, error) {
+	var withInput usecase.HasInputPort
+
+	if !usecase.As(u, &withInput) || withInput.InputPort() == nil {
+		return nil, nil
+	}
+
+	p := usecase.PortInfoOf(withInput.InputPort())
+	fresh := reflect.New(p.Type)
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, fresh.Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.IsPtr {
+		return fresh.Interface(), nil
+	}
+
+	return fresh.Elem().Interface(), nil
+}
@@ -0,0 +1,54 @@
+package usecasetest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+func TestReplayRecord_failures(t *testing.T) {
+	u := usecase.NewInteractor(func(ctx context.Context, input int, output *int) error {
+		switch input {
+		case 1:
+			return status.NotFound
+		case 2:
+			return usecase.Error{AppCode: 2, StatusCode: status.NotFound}
+		case 4:
+			return usecase.Error{AppCode: 4, Value: status.NotFound}
+		}
+
+		*output = input * 2
+
+		return nil
+	})
+
+	for _, tc := range []struct {
+		rec      Record
+		failures []string
+	}{
+		{rec: Record{Input: json.RawMessage(`3`), Output: json.RawMessage(`6`)}},
+		{rec: Record{Input: json.RawMessage(`1`), Status: "NOT_FOUND"}},
+		{rec: Record{Input: json.RawMessage(`4`), Status: "NOT_FOUND", AppCode: 4}},
+		{rec: Record{Input: json.RawMessage(`3`), Output: json.RawMessage(`7`)}, failures: []string{"output differs"}},
+		{rec: Record{Input: json.RawMessage(`1`)}, failures: []string{"unexpected error: not found"}},
+		{rec: Record{Input: json.RawMessage(`3`), Status: "NOT_FOUND", Error: "not found"}, failures: []string{"error with status NOT_FOUND expected"}},
+		{rec: Record{Input: json.RawMessage(`1`), Status: "INTERNAL"}, failures: []string{"status code differs"}},
+		{rec: Record{Input: json.RawMessage(`2`), Status: "NOT_FOUND", AppCode: 1}, failures: []string{"app code differs"}},
+		{rec: Record{Input: json.RawMessage(`"a"`)}, failures: []string{"decoding input"}},
+	} {
+		r := &recorder{}
+		replayRecord(r, u, tc.rec)
+
+		assert.Len(t, r.failures, len(tc.failures), "%v", r.failures)
+
+		for i, f := range tc.failures {
+			if i < len(r.failures) {
+				assert.Contains(t, r.failures[i], f)
+			}
+		}
+	}
+}
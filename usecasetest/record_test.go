@@ -0,0 +1,70 @@
+package usecasetest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/usecasetest"
+)
+
+func TestRecorder(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	rec := usecasetest.NewRecorder(buf)
+
+	u := usecase.Wrap(newOrderUseCase(), rec)
+	ctx := context.Background()
+
+	out := orderOutput{}
+	require.NoError(t, u.Interact(ctx, &orderInput{ID: 1}, &out))
+	assert.Error(t, u.Interact(ctx, &orderInput{ID: 13}, &out))
+	assert.Error(t, u.Interact(ctx, &orderInput{ID: 0}, &out))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var r usecasetest.Record
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &r))
+	assert.Equal(t, "usecasetest_test.newOrderUseCase", r.Name)
+	assert.JSONEq(t, `{"id":1}`, string(r.Input))
+	assert.JSONEq(t, `{"id":1,"status":"new"}`, string(r.Output))
+	assert.Empty(t, r.Status)
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &r))
+	assert.Equal(t, "FAILED_PRECONDITION", r.Status)
+	assert.Equal(t, 1001, r.AppCode)
+	assert.Equal(t, "failed precondition: order is locked", r.Error)
+
+	// Recorder keeps metadata of wrapped use case available.
+	var withInput usecase.HasInputPort
+
+	assert.True(t, usecase.As(u, &withInput))
+
+	// Recorded interactions are reproduced by the same use case.
+	usecasetest.Replay(t, newOrderUseCase(), strings.NewReader(buf.String()+"\n"+
+		`{"name":"other","input":{"id":0},"status":"OK"}`+"\n"))
+}
+
+func TestRecorder_OnError(t *testing.T) {
+	var errs []error
+
+	rec := usecasetest.NewRecorder(bytes.NewBuffer(nil))
+	rec.OnError = func(ctx context.Context, err error) {
+		errs = append(errs, err)
+	}
+
+	u := usecase.Wrap(usecase.Interact(func(ctx context.Context, input, output interface{}) error {
+		return nil
+	}), rec)
+
+	require.NoError(t, u.Interact(context.Background(), func() {}, make(chan int)))
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "encoding input")
+	assert.Contains(t, errs[1].Error(), "encoding output")
+}
@@ -0,0 +1,213 @@
+package usecasetest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+// Call is a recorded invocation of Stub.
+type Call struct {
+	Input  interface{}
+	Output interface{}
+	Err    error
+}
+
+// Stub is a scripted use case interactor that keeps ports and information of interactor it stands in for.
+//
+// Responses for matching inputs take precedence over sequential responses.
+// Sequential responses are used once in order of addition, the last one is repeated.
+// Call without scripted response fails with status.Unimplemented.
+type Stub struct {
+	usecase.IOInteractor
+
+	mu         sync.Mutex
+	responses  []stubResponse
+	lastResp   *stubResponse
+	conditions []*StubCondition
+	calls      []Call
+}
+
+type stubResponse struct {
+	output interface{}
+	err    error
+}
+
+// StubCondition is a response of Stub for matching inputs.
+type StubCondition struct {
+	stub  *Stub
+	match func(input interface{}) bool
+	resp  *stubResponse
+}
+
+// NewStub creates stub with ports and information of use case interactor.
+func NewStub(u usecase.Interactor) *Stub {
+	s := &Stub{}
+	copyInfo(&s.IOInteractor, u)
+	s.Interactor = usecase.Interact(s.interact)
+
+	return s
+}
+
+// Return adds sequential response.
+func (s *Stub) Return(output interface{}, err error) *Stub {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = append(s.responses, stubResponse{output: output, err: err})
+
+	return s
+}
+
+// On adds condition for input, response is defined with Return.
+func (s *Stub) On(match func(input interface{}) bool) *StubCondition {
+	c := &StubCondition{stub: s, match: match}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.conditions = append(s.conditions, c)
+
+	return c
+}
+
+// Return sets response for matching inputs.
+func (c *StubCondition) Return(output interface{}, err error) *Stub {
+	c.stub.mu.Lock()
+	defer c.stub.mu.Unlock()
+
+	c.resp = &stubResponse{output: output, err: err}
+
+	return c.stub
+}
+
+// Calls returns recorded invocations.
+func (s *Stub) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Call(nil), s.calls...)
+}
+
+func (s *Stub) interact(_ context.Context, input, output interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := s.response(input)
+
+	var err error
+
+	if resp == nil {
+		err = fmt.Errorf("%w: no scripted response for input %+v", status.Unimplemented, input)
+	} else {
+		err = resp.err
+
+		if resp.output != nil {
+			if oerr := setOutput(output, resp.output); oerr != nil {
+				err = oerr
+			}
+		}
+	}
+
+	s.calls = append(s.calls, Call{Input: input, Output: output, Err: err})
+
+	return err
+}
+
+func (s *Stub) response(input interface{}) *stubResponse {
+	for _, c := range s.conditions {
+		if c.resp != nil && c.match(input) {
+			return c.resp
+		}
+	}
+
+	if len(s.responses) > 0 {
+		resp := s.responses[0]
+		s.responses = s.responses[1:]
+		s.lastResp = &resp
+
+		return &resp
+	}
+
+	return s.lastResp
+}
+
+// setOutput copies scripted value into output port.
+func setOutput(output, value interface{}) error {
+	dst := reflect.ValueOf(output)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("%w of output: %T, expected pointer", usecase.ErrInvalidType, output)
+	}
+
+	src := reflect.ValueOf(value)
+	if src.Type() != dst.Type().Elem() && src.Kind() == reflect.Ptr {
+		// Nil pointer mimics "return nil, err" and leaves output intact.
+		if src.IsNil() {
+			if src.Type().Elem().AssignableTo(dst.Type().Elem()) {
+				return nil
+			}
+
+			return fmt.Errorf("%w of scripted output: %T, expected: %T", usecase.ErrInvalidType, value, output)
+		}
+
+		src = src.Elem()
+	}
+
+	if !src.Type().AssignableTo(dst.Type().Elem()) {
+		return fmt.Errorf("%w of scripted output: %T, expected: %T", usecase.ErrInvalidType, value, output)
+	}
+
+	dst.Elem().Set(src)
+
+	return nil
+}
+
+// copyInfo copies ports and information of use case interactor.
+func copyInfo(dst *usecase.IOInteractor, u usecase.Interactor) {
+	var (
+		withInput          usecase.HasInputPort
+		withOutput         usecase.HasOutputPort
+		withName           usecase.HasName
+		withTitle          usecase.HasTitle
+		withDescription    usecase.HasDescription
+		withTags           usecase.HasTags
+		withExpectedErrors usecase.HasExpectedErrors
+		withIsDeprecated   usecase.HasIsDeprecated
+	)
+
+	if usecase.As(u, &withInput) {
+		dst.Input = withInput.InputPort()
+	}
+
+	if usecase.As(u, &withOutput) {
+		dst.Output = withOutput.OutputPort()
+	}
+
+	if usecase.As(u, &withName) {
+		dst.SetName(withName.Name())
+	}
+
+	if usecase.As(u, &withTitle) {
+		dst.SetTitle(withTitle.Title())
+	}
+
+	if usecase.As(u, &withDescription) {
+		dst.SetDescription(withDescription.Description())
+	}
+
+	if usecase.As(u, &withTags) {
+		dst.SetTags(withTags.Tags()...)
+	}
+
+	if usecase.As(u, &withExpectedErrors) {
+		dst.SetExpectedErrors(withExpectedErrors.ExpectedErrors()...)
+	}
+
+	if usecase.As(u, &withIsDeprecated) {
+		dst.SetIsDeprecated(withIsDeprecated.IsDeprecated())
+	}
+}
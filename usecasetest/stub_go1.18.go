@@ -0,0 +1,79 @@
+//go:build go1.18
+// +build go1.18
+
+package usecasetest
+
+import (
+	"context"
+
+	"github.com/swaggest/usecase"
+)
+
+// StubOf is a scripted stub of generic use case interactor.
+type StubOf[i, o any] struct {
+	*Stub
+}
+
+// CallOf is a recorded invocation of StubOf.
+type CallOf[i, o any] struct {
+	Input  i
+	Output *o
+	Err    error
+}
+
+// NewStubOf creates stub with ports and information of generic use case interactor.
+func NewStubOf[i, o any](u usecase.IOInteractorOf[i, o]) StubOf[i, o] {
+	return StubOf[i, o]{Stub: NewStub(u)}
+}
+
+// Return adds sequential response.
+func (s StubOf[i, o]) Return(output o, err error) StubOf[i, o] {
+	s.Stub.Return(output, err)
+
+	return s
+}
+
+// ReturnErr adds sequential error response.
+func (s StubOf[i, o]) ReturnErr(err error) StubOf[i, o] {
+	s.Stub.Return(nil, err)
+
+	return s
+}
+
+// On adds response for matching inputs.
+func (s StubOf[i, o]) On(match func(input i) bool, output o, err error) StubOf[i, o] {
+	s.Stub.On(func(input interface{}) bool {
+		v, ok := input.(i)
+
+		return ok && match(v)
+	}).Return(output, err)
+
+	return s
+}
+
+// Calls returns recorded invocations.
+func (s StubOf[i, o]) Calls() []CallOf[i, o] {
+	calls := s.Stub.Calls()
+	res := make([]CallOf[i, o], 0, len(calls))
+
+	for _, c := range calls {
+		tc := CallOf[i, o]{Err: c.Err}
+		tc.Input, _ = c.Input.(i)    //nolint:errcheck // Zero value is used for mismatched type.
+		tc.Output, _ = c.Output.(*o) //nolint:errcheck // Nil is used for mismatched type.
+
+		res = append(res, tc)
+	}
+
+	return res
+}
+
+// IOInteractorOf returns generic use case interactor backed by stub.
+func (s StubOf[i, o]) IOInteractorOf() usecase.IOInteractorOf[i, o] {
+	u := usecase.IOInteractorOf[i, o]{}
+	u.IOInteractor = s.IOInteractor
+	u.InteractFunc = func(ctx context.Context, input i, output *o) error {
+		return s.Interact(ctx, input, output)
+	}
+
+	return u
+}
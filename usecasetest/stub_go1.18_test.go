@@ -0,0 +1,48 @@
+//go:build go1.18
+// +build go1.18
+
+package usecasetest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+	"github.com/swaggest/usecase/usecasetest"
+)
+
+func TestNewStubOf(t *testing.T) {
+	u := usecase.NewInteractor(func(ctx context.Context, input orderInput, output *orderOutput) error {
+		return status.Unavailable
+	})
+	u.SetTitle("Get Order")
+
+	s := usecasetest.NewStubOf(u).
+		Return(orderOutput{ID: 1, Status: "stub"}, nil).
+		On(func(input orderInput) bool { return input.ID == 13 }, orderOutput{}, errOrderLocked)
+
+	si := s.IOInteractorOf()
+	assert.Equal(t, u.Name(), si.Name())
+	assert.Equal(t, "Get Order", si.Title())
+	assert.IsType(t, orderInput{}, si.InputPort())
+
+	ctx := context.Background()
+	out := orderOutput{}
+
+	require.NoError(t, si.Invoke(ctx, orderInput{ID: 5}, &out))
+	assert.Equal(t, orderOutput{ID: 1, Status: "stub"}, out)
+
+	assert.ErrorIs(t, si.Interact(ctx, orderInput{ID: 13}, &out), errOrderLocked)
+
+	calls := s.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, orderInput{ID: 5}, calls[0].Input)
+	assert.Equal(t, &out, calls[0].Output)
+	assert.ErrorIs(t, calls[1].Err, errOrderLocked)
+
+	s.ReturnErr(status.NotFound)
+	assert.ErrorIs(t, si.Invoke(ctx, orderInput{ID: 6}, &out), status.NotFound)
+}
@@ -0,0 +1,83 @@
+package usecasetest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+	"github.com/swaggest/usecase/usecasetest"
+)
+
+func TestNewStub(t *testing.T) {
+	u := newOrderUseCase()
+	u.SetTitle("Get Order")
+	u.SetTags("orders")
+
+	s := usecasetest.NewStub(u).
+		Return(orderOutput{ID: 1, Status: "stub"}, nil).
+		Return(&orderOutput{ID: 2, Status: "stub"}, nil)
+
+	s.On(func(input interface{}) bool {
+		return input.(*orderInput).ID == 13
+	}).Return(nil, errOrderLocked)
+
+	assert.Equal(t, u.Name(), s.Name())
+	assert.Equal(t, "Get Order", s.Title())
+	assert.Equal(t, []string{"orders"}, s.Tags())
+	assert.Equal(t, u.ExpectedErrors(), s.ExpectedErrors())
+	assert.IsType(t, new(orderInput), s.InputPort())
+	assert.IsType(t, new(orderOutput), s.OutputPort())
+
+	ctx := context.Background()
+	out := orderOutput{}
+
+	require.NoError(t, s.Interact(ctx, &orderInput{ID: 5}, &out))
+	assert.Equal(t, orderOutput{ID: 1, Status: "stub"}, out)
+
+	assert.ErrorIs(t, s.Interact(ctx, &orderInput{ID: 13}, &out), errOrderLocked)
+
+	require.NoError(t, s.Interact(ctx, &orderInput{ID: 6}, &out))
+	assert.Equal(t, orderOutput{ID: 2, Status: "stub"}, out)
+
+	// Last sequential response is repeated.
+	out = orderOutput{}
+	require.NoError(t, s.Interact(ctx, &orderInput{ID: 7}, &out))
+	assert.Equal(t, orderOutput{ID: 2, Status: "stub"}, out)
+
+	calls := s.Calls()
+	require.Len(t, calls, 4)
+	assert.Equal(t, &orderInput{ID: 13}, calls[1].Input)
+	assert.ErrorIs(t, calls[1].Err, errOrderLocked)
+	assert.NoError(t, calls[3].Err)
+}
+
+func TestNewStub_noResponse(t *testing.T) {
+	s := usecasetest.NewStub(newOrderUseCase())
+
+	err := s.Interact(context.Background(), &orderInput{ID: 1}, new(orderOutput))
+	assert.ErrorIs(t, err, status.Unimplemented)
+	assert.Len(t, s.Calls(), 1)
+}
+
+func TestNewStub_invalidOutput(t *testing.T) {
+	s := usecasetest.NewStub(newOrderUseCase()).Return("foo", nil).Return((*string)(nil), nil)
+
+	err := s.Interact(context.Background(), &orderInput{ID: 1}, new(orderOutput))
+	assert.ErrorIs(t, err, usecase.ErrInvalidType)
+
+	err = s.Interact(context.Background(), &orderInput{ID: 1}, new(orderOutput))
+	assert.ErrorIs(t, err, usecase.ErrInvalidType)
+}
+
+func TestNewStub_nilOutput(t *testing.T) {
+	s := usecasetest.NewStub(newOrderUseCase()).Return((*orderOutput)(nil), errOrderLocked)
+
+	out := orderOutput{ID: 1}
+
+	err := s.Interact(context.Background(), &orderInput{ID: 1}, &out)
+	assert.ErrorIs(t, err, errOrderLocked)
+	assert.Equal(t, orderOutput{ID: 1}, out)
+}
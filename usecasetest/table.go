@@ -0,0 +1,225 @@
+package usecasetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+// UpdateGolden enables writing actual outputs to golden files instead of comparing them.
+//
+// It can be controlled with a flag in test package, e.g.
+//
+//	func init() {
+//		flag.BoolVar(&usecasetest.UpdateGolden, "update", false, "update golden files")
+//	}
+var UpdateGolden = false
+
+// Case is a test case of use case interaction.
+type Case struct {
+	Name string
+
+	// Context prepares context of interaction, optional.
+	Context func(ctx context.Context) context.Context
+
+	// Input is a value of input port, it is copied into a fresh input port value.
+	Input interface{}
+
+	// Output is an expected value of output port, optional.
+	Output interface{}
+
+	// Golden is a path to JSON file with expected value of output port, optional.
+	Golden string
+
+	// Err is an expected error, it is matched with usecase.IsExpected.
+	Err error
+
+	// StatusCode is an expected status code of error.
+	StatusCode status.Code
+
+	// AppCode is an expected application code of error.
+	AppCode int
+}
+
+// Run runs table-driven test cases against use case interactor.
+//
+// Every case gets fresh input and output values created from ports of interactor.
+// Errors of interactor are checked to be listed in its expected errors.
+func Run(t *testing.T, u usecase.Interactor, cases []Case) {
+	t.Helper()
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.Name, func(t *testing.T) {
+			t.Helper()
+
+			runCase(t, u, c)
+		})
+	}
+}
+
+func runCase(t testing.TB, u usecase.Interactor, c Case) {
+	t.Helper()
+
+	input, err := newInput(u, c.Input)
+	if err != nil {
+		t.Errorf("preparing input: %v", err)
+
+		return
+	}
+
+	output := usecase.NewOutput(u)
+
+	ctx := context.Background()
+	if c.Context != nil {
+		ctx = c.Context(ctx)
+	}
+
+	err = u.Interact(ctx, input, output)
+
+	if !checkError(t, u, c, err) || err != nil {
+		return
+	}
+
+	if c.Output != nil {
+		assert.Equal(t, deref(c.Output), deref(output), "unexpected output")
+	}
+
+	if c.Golden != "" {
+		checkGolden(t, c.Golden, output)
+	}
+}
+
+// checkError asserts error expectations and returns true if they are met.
+func checkError(t testing.TB, u usecase.Interactor, c Case, err error) bool {
+	t.Helper()
+
+	if c.Err == nil && c.StatusCode == 0 && c.AppCode == 0 {
+		return assert.NoError(t, err)
+	}
+
+	if err == nil {
+		t.Errorf("error expected, got nil")
+
+		return false
+	}
+
+	ok := true
+
+	if c.Err != nil && !usecase.IsExpected(err, c.Err) {
+		t.Errorf("error %q does not match %q", err.Error(), c.Err.Error())
+
+		ok = false
+	}
+
+	if c.StatusCode != 0 && !(usecase.ErrorMatcher{StatusCode: c.StatusCode}).Match(err) {
+		t.Errorf("error %q does not have status %s", err.Error(), c.StatusCode.String())
+
+		ok = false
+	}
+
+	if c.AppCode != 0 && !(usecase.ErrorMatcher{AppCode: c.AppCode}).Match(err) {
+		t.Errorf("error %q does not have app code %d", err.Error(), c.AppCode)
+
+		ok = false
+	}
+
+	return checkExpectedErrors(t, u, err) && ok
+}
+
+// checkExpectedErrors asserts that error is listed in expected errors of interactor.
+func checkExpectedErrors(t testing.TB, u usecase.Interactor, err error) bool {
+	t.Helper()
+
+	var withExpectedErrors usecase.HasExpectedErrors
+
+	if err == nil || !usecase.As(u, &withExpectedErrors) {
+		return true
+	}
+
+	if usecase.IsExpected(err, withExpectedErrors.ExpectedErrors()...) {
+		return true
+	}
+
+	t.Errorf("error %q is not listed in ExpectedErrors()", err.Error())
+
+	return false
+}
+
+func checkGolden(t testing.TB, path string, output interface{}) {
+	t.Helper()
+
+	actual, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		t.Errorf("encoding output: %v", err)
+
+		return
+	}
+
+	if UpdateGolden {
+		if err := ioutil.WriteFile(path, append(actual, '\n'), 0o600); err != nil {
+			t.Errorf("writing golden file: %v", err)
+		}
+
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path) //nolint:gosec // Path is provided by test.
+	if err != nil {
+		t.Errorf("reading golden file: %v", err)
+
+		return
+	}
+
+	assert.JSONEq(t, string(expected), string(actual), "output does not match golden file %s", path)
+}
+
+// newInput creates fresh value of input port and copies value into it.
+func newInput(u usecase.Interactor, value interface{}) (interface{}, error) {
+	input := usecase.NewInput(u)
+	if input == nil {
+		return value, nil
+	}
+
+	if value == nil {
+		return input, nil
+	}
+
+	p := usecase.PortInfoOf(input)
+	pt := p.Type
+	v := reflect.ValueOf(value)
+
+	if v.Kind() == reflect.Ptr && v.Type().Elem() == pt {
+		v = v.Elem()
+	}
+
+	if v.Type() != pt {
+		return nil, fmt.Errorf("%w of input: %T, expected: %T", usecase.ErrInvalidType, value, input)
+	}
+
+	if !p.IsPtr {
+		return v.Interface(), nil
+	}
+
+	reflect.ValueOf(input).Elem().Set(v)
+
+	return input, nil
+}
+
+// deref returns value that pointer points to.
+func deref(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		return rv.Elem().Interface()
+	}
+
+	return v
+}
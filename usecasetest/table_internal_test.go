@@ -0,0 +1,70 @@
+package usecasetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+)
+
+// recorder collects reported failures instead of failing test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Name() string {
+	return "recorder"
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestRunCase_failures(t *testing.T) {
+	u := usecase.NewIOI(new(int), new(int), func(ctx context.Context, input, output interface{}) error {
+		switch *input.(*int) {
+		case 1:
+			return status.NotFound
+		case 2:
+			return status.Internal
+		}
+
+		*output.(*int) = *input.(*int)
+
+		return nil
+	})
+	u.SetExpectedErrors(status.NotFound)
+
+	for _, tc := range []struct {
+		c        Case
+		failures []string
+	}{
+		{c: Case{Input: 3, Output: 3}},
+		{c: Case{Input: 3, Output: 4}, failures: []string{"Not equal"}},
+		{c: Case{Input: 3, StatusCode: status.NotFound}, failures: []string{"error expected, got nil"}},
+		{c: Case{Input: 1}, failures: []string{"Received unexpected error"}},
+		{c: Case{Input: 1, StatusCode: status.InvalidArgument}, failures: []string{`error "not found" does not have status INVALID_ARGUMENT`}},
+		{c: Case{Input: 1, AppCode: 123}, failures: []string{`error "not found" does not have app code 123`}},
+		{c: Case{Input: 1, Err: status.Internal}, failures: []string{`error "not found" does not match "internal"`}},
+		{c: Case{Input: 2, StatusCode: status.Internal}, failures: []string{`error "internal" is not listed in ExpectedErrors()`}},
+		{c: Case{Input: "3"}, failures: []string{"preparing input: invalid type of input: string, expected: *int"}},
+		{c: Case{Input: 3, Golden: "testdata/missing.json"}, failures: []string{"reading golden file"}},
+	} {
+		r := &recorder{}
+		runCase(r, u, tc.c)
+
+		assert.Len(t, r.failures, len(tc.failures), "%v", r.failures)
+
+		for i, f := range tc.failures {
+			if i < len(r.failures) {
+				assert.Contains(t, r.failures[i], f)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package usecasetest_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/swaggest/usecase"
+	"github.com/swaggest/usecase/status"
+	"github.com/swaggest/usecase/usecasetest"
+)
+
+type orderInput struct {
+	ID int `json:"id"`
+}
+
+type orderOutput struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+}
+
+var errOrderLocked = usecase.Error{AppCode: 1001, StatusCode: status.FailedPrecondition}
+
+func newOrderUseCase() usecase.IOInteractor {
+	u := usecase.NewIOI(new(orderInput), new(orderOutput), func(ctx context.Context, input, output interface{}) error {
+		in := input.(*orderInput)
+		out := output.(*orderOutput)
+
+		switch in.ID {
+		case 0:
+			return status.Wrap(errors.New("missing id"), status.InvalidArgument)
+		case 13:
+			return usecase.Error{
+				AppCode:    1001,
+				StatusCode: status.FailedPrecondition,
+				Value:      errors.New("order is locked"),
+			}
+		}
+
+		out.ID = in.ID
+		out.Status = "new"
+
+		return nil
+	})
+
+	u.SetExpectedErrors(status.InvalidArgument, errOrderLocked)
+
+	return u
+}
+
+func TestRun(t *testing.T) {
+	usecasetest.Run(t, newOrderUseCase(), []usecasetest.Case{
+		{
+			Name:   "found",
+			Input:  orderInput{ID: 1},
+			Output: orderOutput{ID: 1, Status: "new"},
+		},
+		{
+			Name:   "pointer input and output",
+			Input:  &orderInput{ID: 2},
+			Output: &orderOutput{ID: 2, Status: "new"},
+		},
+		{
+			Name:   "golden",
+			Input:  orderInput{ID: 3},
+			Golden: "testdata/order3.json",
+		},
+		{
+			Name:       "invalid",
+			Input:      orderInput{},
+			StatusCode: status.InvalidArgument,
+		},
+		{
+			Name:       "locked",
+			Input:      orderInput{ID: 13},
+			Err:        errOrderLocked,
+			AppCode:    1001,
+			StatusCode: status.FailedPrecondition,
+		},
+	})
+}
+
+func TestRun_generic(t *testing.T) {
+	type ctxKey struct{}
+
+	u := usecase.NewInteractor(func(ctx context.Context, input int, output *string) error {
+		*output = ctx.Value(ctxKey{}).(string)
+
+		return nil
+	})
+
+	usecasetest.Run(t, u, []usecasetest.Case{
+		{
+			Name:  "value input",
+			Input: 1,
+			Context: func(ctx context.Context) context.Context {
+				return context.WithValue(ctx, ctxKey{}, "foo")
+			},
+			Output: "foo",
+		},
+	})
+}
+
+func TestUpdateGolden(t *testing.T) {
+	usecasetest.UpdateGolden = true
+
+	defer func() {
+		usecasetest.UpdateGolden = false
+	}()
+
+	dir, err := ioutil.TempDir("", "usecasetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	golden := filepath.Join(dir, "order.json")
+
+	usecasetest.Run(t, newOrderUseCase(), []usecasetest.Case{
+		{Name: "write", Input: orderInput{ID: 4}, Golden: golden},
+	})
+
+	usecasetest.UpdateGolden = false
+
+	usecasetest.Run(t, newOrderUseCase(), []usecasetest.Case{
+		{Name: "read", Input: orderInput{ID: 4}, Golden: golden},
+	})
+}